@@ -0,0 +1,335 @@
+// Command import builds the SQLite postal code database from the Polish postal code CSV,
+// replacing helpers/create_db.py. Run it from the go/ directory:
+//
+//	go run ./cmd/import -csv ../postal_codes_poland.csv -population ../helpers/population_data.csv -db ../postal_codes.db
+//
+// The database is written to a temporary file and renamed over -db when complete, so re-running the
+// import is idempotent and a failed run leaves the existing database untouched.
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"postal-api/internal/database"
+	"postal-api/internal/utils"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// progressInterval is the number of CSV rows between progress log lines
+const progressInterval = 10000
+
+// Columns of the postal code CSV
+const (
+	colPostalCode = iota
+	colCity
+	colStreet
+	colHouseNumbers
+	colMunicipality
+	colCounty
+	colProvince
+	postalCodeColumns
+)
+
+// Columns of the population CSV
+const (
+	popColCity       = 0
+	popColCounty     = 1
+	popColProvince   = 2
+	popColPopulation = 4
+)
+
+// cityMunicipalities are cities split into districts in the CSV; their records use the municipality as city_clean
+var cityMunicipalities = map[string]bool{
+	"Warszawa": true, "Łódź": true, "Kraków": true, "Wrocław": true, "Poznań": true, "Jelenia Góra": true,
+	"Bieruń": true, "Zawiercie": true, "Będzin": true, "Mikołów": true, "Orzesze": true, "Kędzierzyn-Koźle": true,
+}
+
+// cityAliases maps CSV city names with a district suffix to the city they belong to
+var cityAliases = map[string]string{
+	"Kraśnik (Kraśnik Fabryczny)":     "Kraśnik",
+	"Darłowo (Darłówko)":              "Darłowo",
+	"Police (Jasienica)":              "Police",
+	"Łaziska Górne (Łaziska Średnie)": "Łaziska Górne",
+	"Łaziska Górne (Łaziska Dolne)":   "Łaziska Górne",
+}
+
+// createTable is the postal_codes schema; indexes are added by database.EnsureIndexes after loading
+const createTable = `CREATE TABLE postal_codes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	postal_code TEXT NOT NULL,
+	city TEXT,
+	street TEXT,
+	house_numbers TEXT,
+	municipality TEXT,
+	county TEXT,
+	province TEXT,
+	city_normalized TEXT,
+	street_normalized TEXT,
+	city_clean TEXT,
+	population INTEGER
+)`
+
+const insertRecord = `INSERT INTO postal_codes
+	(postal_code, city, street, house_numbers, municipality, county, province, city_normalized, street_normalized, city_clean, population)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+func main() {
+	csvPath := flag.String("csv", "../postal_codes_poland.csv", "postal code CSV (PNA, Miejscowość, Ulica, Numery, Gmina, Powiat, Województwo)")
+	populationPath := flag.String("population", "../helpers/population_data.csv", "population CSV; cities not listed get population 1")
+	dbPath := flag.String("db", "../postal_codes.db", "SQLite database to create or replace")
+	flag.Parse()
+
+	if err := run(*csvPath, *populationPath, *dbPath); err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+}
+
+// run imports the CSV into a temporary database and moves it over dbPath
+func run(csvPath, populationPath, dbPath string) error {
+	populations, err := loadPopulations(populationPath)
+	if err != nil {
+		return err
+	}
+	log.Printf("Loaded population data for %d cities", len(populations))
+
+	tmpPath := dbPath + ".tmp"
+	if err := os.Remove(tmpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale temporary database: %w", err)
+	}
+
+	stats, err := buildDatabase(csvPath, tmpPath, populations)
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	log.Printf("Creating indexes")
+	if err := database.InitializeWithPath(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	err = database.EnsureIndexes()
+	database.Close()
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		return fmt.Errorf("failed to replace database: %w", err)
+	}
+
+	log.Printf("Imported %d CSV rows into %d records (%d split house number lists, %d records with population data)",
+		stats.rows, stats.records, stats.splitLists, stats.withPopulation)
+	log.Printf("Database written to %s", dbPath)
+	return nil
+}
+
+// importStats summarizes an import for the final log line
+type importStats struct {
+	rows           int
+	records        int
+	splitLists     int
+	withPopulation int
+}
+
+// populationKey identifies a city in the population data
+type populationKey struct {
+	city, county, province string
+}
+
+// loadPopulations reads the population CSV keyed by title-cased city, county and province.
+// A missing file is not an error; every city then gets population 1.
+func loadPopulations(path string) (map[populationKey]int, error) {
+	populations := map[populationKey]int{}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("Population file %s not found, using population 1 for every city", path)
+		return populations, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open population file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("failed to read population header: %w", err)
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read population file: %w", err)
+		}
+
+		population, err := strconv.Atoi(strings.TrimSpace(record[popColPopulation]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid population for %s: %w", record[popColCity], err)
+		}
+		key := populationKey{titleCase(strings.TrimSpace(record[popColCity])), record[popColCounty], record[popColProvince]}
+		populations[key] = population
+	}
+	return populations, nil
+}
+
+// buildDatabase creates the schema in a new SQLite file and loads every CSV row in a single transaction
+func buildDatabase(csvPath, dbPath string, populations map[populationKey]int) (importStats, error) {
+	var stats importStats
+
+	file, err := os.Open(csvPath)
+	if err != nil {
+		return stats, fmt.Errorf("failed to open CSV: %w", err)
+	}
+	defer file.Close()
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		return stats, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(createTable); err != nil {
+		return stats, fmt.Errorf("failed to create table: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return stats, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	insert, err := tx.Prepare(insertRecord)
+	if err != nil {
+		return stats, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer insert.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = postalCodeColumns
+	if _, err := reader.Read(); err != nil {
+		return stats, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stats, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		stats.rows++
+
+		cityClean := cleanCityName(row[colCity], row[colMunicipality], row[colCounty])
+		population := 1
+		if value, ok := populations[populationKey{cityClean, row[colCounty], row[colProvince]}]; ok {
+			population = value
+		}
+
+		// Comma-separated house number lists become one record per range so each can be matched on its own.
+		// A list of nothing but commas would silently drop the row, so it fails the import instead.
+		houseNumbers := []interface{}{nil}
+		if row[colHouseNumbers] != "" {
+			parts := splitHouseNumbers(row[colHouseNumbers])
+			if len(parts) == 0 {
+				return stats, fmt.Errorf("row %d: house numbers %q list no ranges", stats.rows, row[colHouseNumbers])
+			}
+			houseNumbers = houseNumbers[:0]
+			for _, part := range parts {
+				houseNumbers = append(houseNumbers, part)
+			}
+			if len(houseNumbers) > 1 {
+				stats.splitLists++
+			}
+		}
+
+		for _, houseNumber := range houseNumbers {
+			_, err := insert.Exec(row[colPostalCode], row[colCity], nullable(row[colStreet]), houseNumber,
+				row[colMunicipality], row[colCounty], row[colProvince],
+				utils.NormalizePolishText(cityClean), nullable(utils.NormalizePolishText(row[colStreet])),
+				cityClean, population)
+			if err != nil {
+				return stats, fmt.Errorf("failed to insert row %d: %w", stats.rows, err)
+			}
+			stats.records++
+			if population > 1 {
+				stats.withPopulation++
+			}
+		}
+
+		if stats.rows%progressInterval == 0 {
+			log.Printf("Processed %d rows (%d records)", stats.rows, stats.records)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return stats, fmt.Errorf("failed to commit import: %w", err)
+	}
+	return stats, nil
+}
+
+// cleanCityName consolidates district names into the city used in API responses, e.g. the districts
+// of Warszawa, and title-cases everything else
+func cleanCityName(city, municipality, county string) string {
+	if cityMunicipalities[municipality] {
+		return municipality
+	}
+	if municipality == "Józefów" && county == "otwocki" {
+		return "Józefów"
+	}
+	if alias, ok := cityAliases[city]; ok {
+		return alias
+	}
+	return titleCase(strings.TrimSpace(city))
+}
+
+// titleCase uppercases the first letter of every word and lowercases the rest. Like Python's
+// str.title, which built the original database, a word starts after any non-letter, so
+// "kędzierzyn-koźle" becomes "Kędzierzyn-Koźle".
+func titleCase(text string) string {
+	var builder strings.Builder
+	builder.Grow(len(text))
+	previousIsLetter := false
+	for _, r := range text {
+		if previousIsLetter {
+			builder.WriteRune(unicode.ToLower(r))
+		} else {
+			builder.WriteRune(unicode.ToTitle(r))
+		}
+		previousIsLetter = unicode.IsLetter(r)
+	}
+	return builder.String()
+}
+
+// splitHouseNumbers splits a comma-separated house number list into its trimmed, non-empty ranges
+func splitHouseNumbers(houseNumbers string) []string {
+	var parts []string
+	for _, part := range strings.Split(houseNumbers, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// nullable stores empty CSV fields as NULL
+func nullable(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTitleCaseMatchesPythonTitle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"warszawa", "Warszawa"},
+		{"ŁÓDŹ", "Łódź"},
+		{"kędzierzyn-koźle", "Kędzierzyn-Koźle"},
+		{"nowy dwór gdański", "Nowy Dwór Gdański"},
+		{"kraków (kraków-śródmieście)", "Kraków (Kraków-Śródmieście)"},
+	}
+
+	for _, tt := range tests {
+		if got := titleCase(tt.input); got != tt.expected {
+			t.Errorf("titleCase(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestCleanCityName(t *testing.T) {
+	tests := []struct {
+		city, municipality, county string
+		expected                   string
+	}{
+		{"Warszawa (Bemowo)", "Warszawa", "Warszawa", "Warszawa"},
+		{"Kraśnik (Kraśnik Fabryczny)", "Kraśnik", "kraśnicki", "Kraśnik"},
+		{"Józefów (Michalin)", "Józefów", "otwocki", "Józefów"},
+		{" Abisynia ", "Karsin", "kościerski", "Abisynia"},
+	}
+
+	for _, tt := range tests {
+		if got := cleanCityName(tt.city, tt.municipality, tt.county); got != tt.expected {
+			t.Errorf("cleanCityName(%q, %q, %q) = %q, want %q", tt.city, tt.municipality, tt.county, got, tt.expected)
+		}
+	}
+}
+
+func TestSplitHouseNumbers(t *testing.T) {
+	got := splitHouseNumbers("270-336(p), 283-335(n),, 1a ")
+	if want := []string{"270-336(p)", "283-335(n)", "1a"}; !slices.Equal(got, want) {
+		t.Errorf("splitHouseNumbers = %q, want %q", got, want)
+	}
+}
+
+func TestRunImportsAndReplacesDatabase(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "postal_codes.csv")
+	populationPath := filepath.Join(dir, "population.csv")
+	dbPath := filepath.Join(dir, "postal_codes.db")
+
+	writeFile(t, csvPath, "PNA,Miejscowość,Ulica,Numery,Gmina,Powiat,Województwo\n"+
+		"31-146,Kraków (Kraków-Śródmieście),Długa,\"2-DK(p), 1-DK(n)\",Kraków,Kraków,małopolskie\n"+
+		"83-440,Abisynia,,,Karsin,kościerski,pomorskie\n")
+	writeFile(t, populationPath, "Miasto,Powiat,Województwo,Powierzchnia,Liczba ludności (01.01.2021),Gęstość\n"+
+		"Kraków,Kraków,małopolskie,32685,779966,2386\n")
+
+	// Importing twice must leave the same single copy of the data
+	for i := 0; i < 2; i++ {
+		if err := run(csvPath, populationPath, dbPath); err != nil {
+			t.Fatalf("import %d failed: %v", i+1, err)
+		}
+	}
+
+	db, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open imported database: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM postal_codes").Scan(&count); err != nil {
+		t.Fatalf("count failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 records, got %d", count)
+	}
+
+	var streetNormalized, cityClean string
+	var population int
+	err = db.QueryRow("SELECT street_normalized, city_clean, population FROM postal_codes WHERE house_numbers = '1-DK(n)'").
+		Scan(&streetNormalized, &cityClean, &population)
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if streetNormalized != "Dluga" || cityClean != "Kraków" || population != 779966 {
+		t.Errorf("unexpected record: street_normalized=%q city_clean=%q population=%d", streetNormalized, cityClean, population)
+	}
+
+	var street sql.NullString
+	if err := db.QueryRow("SELECT street FROM postal_codes WHERE city = 'Abisynia'").Scan(&street); err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if street.Valid {
+		t.Errorf("expected a NULL street, got %q", street.String)
+	}
+}
+
+func TestRunRejectsHouseNumbersWithoutRanges(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "postal_codes.csv")
+	dbPath := filepath.Join(dir, "postal_codes.db")
+	writeFile(t, csvPath, "PNA,Miejscowość,Ulica,Numery,Gmina,Powiat,Województwo\n"+
+		"31-146,Kraków (Kraków-Śródmieście),Długa,\" , ,\",Kraków,Kraków,małopolskie\n")
+
+	err := run(csvPath, filepath.Join(dir, "missing.csv"), dbPath)
+	if err == nil || !strings.Contains(err.Error(), "row 1") {
+		t.Fatalf("expected the comma-only house numbers of row 1 to be rejected, got %v", err)
+	}
+	if _, err := os.Stat(dbPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected no database to be written, got %v", err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
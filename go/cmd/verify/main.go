@@ -0,0 +1,92 @@
+// Command verify checks that the normalized columns of a postal code database match what the API's
+// NormalizePolishText produces, so databases built by helpers/create_db.py or an older import
+// search the same way as the running API. Run it from the go/ directory:
+//
+//	go run ./cmd/verify -db ../postal_codes.db
+//
+// It exits with status 1 when any row differs.
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"postal-api/internal/utils"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Discrepancy is a row whose stored normalized value differs from the normalizer's output
+type Discrepancy struct {
+	ID       int
+	Column   string
+	Source   string
+	Stored   string
+	Expected string
+}
+
+func main() {
+	dbPath := flag.String("db", "../postal_codes.db", "SQLite database to verify")
+	maxReported := flag.Int("max", 20, "maximum number of discrepancies to print")
+	flag.Parse()
+
+	db, err := sql.Open("sqlite3", *dbPath+"?mode=ro")
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	discrepancies, checked, err := verifyNormalizedColumns(db)
+	if err != nil {
+		log.Fatalf("Verification failed: %v", err)
+	}
+
+	for i, d := range discrepancies {
+		if i == *maxReported {
+			fmt.Printf("... and %d more\n", len(discrepancies)-*maxReported)
+			break
+		}
+		fmt.Printf("id %d: %s is %q, expected %q (from %q)\n", d.ID, d.Column, d.Stored, d.Expected, d.Source)
+	}
+	fmt.Printf("Checked %d rows: %d discrepancies\n", checked, len(discrepancies))
+
+	if len(discrepancies) > 0 {
+		os.Exit(1)
+	}
+}
+
+// verifyNormalizedColumns compares city_normalized with the normalized city_clean and street_normalized
+// with the normalized street for every row. NULL and empty values are treated as equal.
+func verifyNormalizedColumns(db *sql.DB) ([]Discrepancy, int, error) {
+	rows, err := db.Query("SELECT id, city_clean, city_normalized, street, street_normalized FROM postal_codes ORDER BY id")
+	if err != nil {
+		return nil, 0, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var discrepancies []Discrepancy
+	checked := 0
+	for rows.Next() {
+		var id int
+		var cityClean, cityNormalized, street, streetNormalized sql.NullString
+		if err := rows.Scan(&id, &cityClean, &cityNormalized, &street, &streetNormalized); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		checked++
+
+		if expected := utils.NormalizePolishText(cityClean.String); cityNormalized.String != expected {
+			discrepancies = append(discrepancies, Discrepancy{id, "city_normalized", cityClean.String, cityNormalized.String, expected})
+		}
+		if expected := utils.NormalizePolishText(street.String); streetNormalized.String != expected {
+			discrepancies = append(discrepancies, Discrepancy{id, "street_normalized", street.String, streetNormalized.String, expected})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return discrepancies, checked, nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestVerifyNormalizedColumnsReportsMismatches(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	statements := []string{
+		"CREATE TABLE postal_codes (id INTEGER PRIMARY KEY, city_clean TEXT, city_normalized TEXT, street TEXT, street_normalized TEXT)",
+		"INSERT INTO postal_codes VALUES (1, 'Łódź', 'Lodz', 'Piotrkowska', 'Piotrkowska')",
+		"INSERT INTO postal_codes VALUES (2, 'Kraków', 'Krakow', NULL, NULL)",
+		"INSERT INTO postal_codes VALUES (3, 'Gdańsk', 'Gdańsk', 'Długa', 'Dluga')",
+		"INSERT INTO postal_codes VALUES (4, 'Sopot', 'Sopot', 'Bohaterów Monte Cassino', NULL)",
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+	}
+
+	discrepancies, checked, err := verifyNormalizedColumns(db)
+	if err != nil {
+		t.Fatalf("verifyNormalizedColumns failed: %v", err)
+	}
+	if checked != 4 {
+		t.Errorf("expected 4 checked rows, got %d", checked)
+	}
+	if len(discrepancies) != 2 {
+		t.Fatalf("expected 2 discrepancies, got %+v", discrepancies)
+	}
+	if d := discrepancies[0]; d.ID != 3 || d.Column != "city_normalized" || d.Expected != "Gdansk" {
+		t.Errorf("unexpected discrepancy %+v", d)
+	}
+	if d := discrepancies[1]; d.ID != 4 || d.Column != "street_normalized" || d.Expected != "Bohaterow Monte Cassino" {
+		t.Errorf("unexpected discrepancy %+v", d)
+	}
+}
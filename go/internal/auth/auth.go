@@ -0,0 +1,30 @@
+// Package auth checks API keys and counts their use, shared by the REST and gRPC servers
+package auth
+
+import (
+	"crypto/subtle"
+	"slices"
+)
+
+// WithAdminKey returns the keys accepted on regular endpoints: the API keys plus the admin key, which is accepted
+// everywhere. Without API keys authentication stays disabled, so the admin key is not added.
+func WithAdminKey(keys []string, adminKey string) []string {
+	if adminKey == "" || len(keys) == 0 {
+		return keys
+	}
+	return append(slices.Clip(keys), adminKey)
+}
+
+// ValidKey reports whether key is one of keys, comparing in constant time
+func ValidKey(keys []string, key string) bool {
+	if key == "" {
+		return false
+	}
+	valid := false
+	for _, candidate := range keys {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+// KeyUsage is the number of requests made with one API key
+type KeyUsage struct {
+	KeyID      string    `json:"key_id"`
+	Requests   int64     `json:"requests"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// UsageResponse lists per-key request counts since the last reset
+type UsageResponse struct {
+	Since time.Time  `json:"since"`
+	Keys  []KeyUsage `json:"keys"`
+}
+
+// UsageCounter counts authenticated requests per API key in memory; counts are lost on restart
+type UsageCounter struct {
+	mu    sync.Mutex
+	since time.Time
+	keys  map[string]*KeyUsage
+}
+
+// Usage holds the counts of REST and gRPC requests reported by /admin/usage
+var Usage = NewUsageCounter()
+
+// NewUsageCounter returns a counter starting from zero
+func NewUsageCounter() *UsageCounter {
+	return &UsageCounter{since: time.Now(), keys: map[string]*KeyUsage{}}
+}
+
+// Record counts one request made with key
+func (u *UsageCounter) Record(key string) {
+	now := time.Now()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage, ok := u.keys[key]
+	if !ok {
+		usage = &KeyUsage{KeyID: KeyID(key)}
+		u.keys[key] = usage
+	}
+	usage.Requests++
+	usage.LastUsedAt = now
+}
+
+// Snapshot returns the counts, busiest key first, and clears them when reset is set
+func (u *UsageCounter) Snapshot(reset bool) UsageResponse {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	response := UsageResponse{Since: u.since.UTC(), Keys: make([]KeyUsage, 0, len(u.keys))}
+	for _, usage := range u.keys {
+		response.Keys = append(response.Keys, *usage)
+	}
+	sort.Slice(response.Keys, func(i, j int) bool {
+		if response.Keys[i].Requests != response.Keys[j].Requests {
+			return response.Keys[i].Requests > response.Keys[j].Requests
+		}
+		return response.Keys[i].KeyID < response.Keys[j].KeyID
+	})
+
+	if reset {
+		u.since = time.Now()
+		u.keys = map[string]*KeyUsage{}
+	}
+	return response
+}
+
+// KeyID identifies an API key in usage reports without revealing it: the first 16 hex digits of its SHA-256
+func KeyID(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:8])
+}
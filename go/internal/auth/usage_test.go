@@ -0,0 +1,25 @@
+package auth
+
+import "testing"
+
+func TestUsageCounterCountsPerKey(t *testing.T) {
+	counter := NewUsageCounter()
+	counter.Record("alpha")
+	counter.Record("beta")
+	counter.Record("beta")
+
+	usage := counter.Snapshot(true)
+	if len(usage.Keys) != 2 {
+		t.Fatalf("expected 2 keys, got %+v", usage.Keys)
+	}
+	if usage.Keys[0].KeyID != KeyID("beta") || usage.Keys[0].Requests != 2 {
+		t.Errorf("expected beta first with 2 requests, got %+v", usage.Keys[0])
+	}
+	if usage.Keys[1].KeyID != KeyID("alpha") || usage.Keys[1].Requests != 1 {
+		t.Errorf("expected alpha second with 1 request, got %+v", usage.Keys[1])
+	}
+
+	if after := counter.Snapshot(false); len(after.Keys) != 0 {
+		t.Errorf("expected no counts after reset, got %+v", after.Keys)
+	}
+}
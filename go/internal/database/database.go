@@ -2,14 +2,23 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
+	"time"
 
+	"postal-api/internal/config"
+
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 )
 
-var db *sql.DB
+var db atomic.Pointer[DB]
+
+// hasCoordinates is set when the postal_codes table has latitude/longitude columns
+var hasCoordinates atomic.Bool
 
 const dbPath = "../postal_codes.db"
 
@@ -22,44 +31,257 @@ type PostalCode struct {
 	Municipality *string `json:"municipality,omitempty" db:"municipality"`
 	County       *string `json:"county,omitempty" db:"county"`
 	Province     string  `json:"province" db:"province"`
+	// MatchedRange is the house_numbers range the searched house number fell into (not stored in the database)
+	MatchedRange *string `json:"matched_range,omitempty" db:"-"`
+	// MatchQuality rates how closely the row matched the searched city and street, best first:
+	// "exact" (names equal the query, ignoring case), "prefix" (names start with the query),
+	// "normalized" (matched only after Polish character normalization) and "partial" (substring,
+	// fallback or fuzzy matches). Every searched name must reach a tier for the row to get it.
+	// Only set by searches (not stored in the database).
+	MatchQuality string   `json:"match_quality,omitempty" db:"-"`
+	Latitude     *float64 `json:"latitude,omitempty" db:"latitude"`
+	Longitude    *float64 `json:"longitude,omitempty" db:"longitude"`
+}
+
+// PostalCodeFields lists the JSON keys of PostalCode that clients may select
+var PostalCodeFields = []string{"postal_code", "city", "street", "house_numbers", "municipality", "county", "province", "matched_range", "match_quality", "latitude", "longitude"}
+
+// IsPostalCodeField reports whether name is a selectable PostalCode JSON key
+func IsPostalCodeField(name string) bool {
+	for _, field := range PostalCodeFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectFields returns a map containing only the requested fields, omitting nil values like the JSON encoding does
+func (pc PostalCode) SelectFields(fields []string) map[string]interface{} {
+	selected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "postal_code":
+			selected[field] = pc.PostalCode
+		case "city":
+			selected[field] = pc.City
+		case "street":
+			if pc.Street != nil {
+				selected[field] = *pc.Street
+			}
+		case "house_numbers":
+			if pc.HouseNumbers != nil {
+				selected[field] = *pc.HouseNumbers
+			}
+		case "municipality":
+			if pc.Municipality != nil {
+				selected[field] = *pc.Municipality
+			}
+		case "county":
+			if pc.County != nil {
+				selected[field] = *pc.County
+			}
+		case "province":
+			selected[field] = pc.Province
+		case "matched_range":
+			if pc.MatchedRange != nil {
+				selected[field] = *pc.MatchedRange
+			}
+		case "match_quality":
+			if pc.MatchQuality != "" {
+				selected[field] = pc.MatchQuality
+			}
+		case "latitude":
+			if pc.Latitude != nil {
+				selected[field] = *pc.Latitude
+			}
+		case "longitude":
+			if pc.Longitude != nil {
+				selected[field] = *pc.Longitude
+			}
+		}
+	}
+	return selected
 }
 
-// CheckDatabaseExists checks if the database file exists
+// CheckDatabaseExists checks if the SQLite database file exists; other drivers are checked when connecting
 func CheckDatabaseExists() bool {
+	if config.DatabaseDriver() != DriverSQLite {
+		return true
+	}
 	_, err := os.Stat(dbPath)
 	return err == nil
 }
 
-// Initialize initializes the database connection
+// Initialize connects to the database selected by DB_DRIVER and creates missing search indexes unless disabled
 func Initialize() error {
-	absPath, err := filepath.Abs(dbPath)
+	var err error
+	if driver := config.DatabaseDriver(); driver == DriverSQLite {
+		err = InitializeWithPath(dbPath)
+	} else {
+		err = InitializeWithDSN(driver, config.DatabaseURL())
+	}
+	if err != nil {
+		return err
+	}
+	if config.SkipIndexCreation() {
+		return nil
+	}
+	return EnsureIndexes()
+}
+
+// Reload connects again through Initialize, e.g. after postal_codes.db was replaced. New queries use the
+// new connection at once while queries already running finish on the old one, which is closed once idle.
+// A missing SQLite file is an error rather than being created empty.
+func Reload() error {
+	if !CheckDatabaseExists() {
+		return errors.New("database file not found")
+	}
+	return Initialize()
+}
+
+// idlePollInterval is how often a replaced connection pool is checked for queries still running
+const idlePollInterval = 10 * time.Millisecond
+
+// drainTimeout is how long a replaced connection pool waits for running queries, which includes
+// unlimited NDJSON exports running under the export timeout
+func drainTimeout() time.Duration {
+	return max(config.QueryTimeout(), config.ExportTimeout())
+}
+
+// closeWhenIdle closes a replaced connection pool once none of its connections are in use, or after
+// timeout, so in-flight requests can finish reading their rows
+func closeWhenIdle(database *DB, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for database.Stats().InUse > 0 && time.Now().Before(deadline) {
+		time.Sleep(idlePollInterval)
+	}
+	database.Close()
+}
+
+// InitializeWithPath initializes the database connection using the given database file
+func InitializeWithPath(path string) error {
+	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return fmt.Errorf("failed to get absolute path: %w", err)
 	}
+	return open(sqliteDialect{}, absPath)
+}
+
+// InitializeWithDSN initializes the database connection using a driver name and data source name
+func InitializeWithDSN(driver, dsn string) error {
+	dialect, err := dialectFor(driver)
+	if err != nil {
+		return err
+	}
+	return open(dialect, dsn)
+}
 
-	database, err := sql.Open("sqlite3", absPath)
+// open connects with the dialect's driver and detects the optional schema features
+func open(dialect Dialect, dsn string) error {
+	conn, err := sql.Open(dialect.DriverName(), dsn)
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
+	database := &DB{DB: conn, dialect: dialect}
 
 	// Test the connection
 	if err := database.Ping(); err != nil {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	db = database
+	// Older databases don't have coordinate columns, so detect them once
+	coordinates, err := detectCoordinateColumns(database)
+	if err != nil {
+		return fmt.Errorf("failed to inspect database schema: %w", err)
+	}
+
+	hasCoordinates.Store(coordinates)
+	hasFTS.Store(detectFTS(database))
+	rememberDatabaseFile(dialect, dsn)
+	if previous := db.Swap(database); previous != nil {
+		go closeWhenIdle(previous, drainTimeout())
+	}
+	markHealthy()
 	return nil
 }
 
+// detectCoordinateColumns checks whether postal_codes has both latitude and longitude columns
+func detectCoordinateColumns(database *DB) (bool, error) {
+	rows, err := database.Query(database.dialect.ColumnsQuery("postal_codes"))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	found := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, err
+		}
+		found[name] = true
+	}
+
+	return found["latitude"] && found["longitude"], rows.Err()
+}
+
+// HasCoordinates reports whether the loaded database has latitude/longitude columns
+func HasCoordinates() bool {
+	return hasCoordinates.Load()
+}
+
+// PostalCodeColumns returns the column list to select for ScanPostalCodes
+func PostalCodeColumns() string {
+	columns := "id, postal_code, city, street, house_numbers, municipality, county, province, city_normalized, street_normalized, city_clean, population"
+	if hasCoordinates.Load() {
+		columns += ", latitude, longitude"
+	}
+	return columns
+}
+
+// ScanPostalCodes scans rows selected with PostalCodeColumns into PostalCode records
+func ScanPostalCodes(rows *sql.Rows) ([]PostalCode, error) {
+	var results []PostalCode
+	for rows.Next() {
+		pc, err := ScanPostalCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, pc)
+	}
+	return results, rows.Err()
+}
+
+// ScanPostalCode scans the current row of PostalCodeColumns, followed by any extra selected values
+func ScanPostalCode(rows *sql.Rows, extra ...interface{}) (PostalCode, error) {
+	var pc PostalCode
+	var id int
+	var cityNormalized, streetNormalized, cityClean interface{}
+	var population interface{}
+	dest := []interface{}{&id, &pc.PostalCode, &pc.City, &pc.Street, &pc.HouseNumbers, &pc.Municipality, &pc.County, &pc.Province, &cityNormalized, &streetNormalized, &cityClean, &population}
+	if hasCoordinates.Load() {
+		dest = append(dest, &pc.Latitude, &pc.Longitude)
+	}
+	dest = append(dest, extra...)
+	err := rows.Scan(dest...)
+	return pc, err
+}
+
 // GetDB returns the database connection
-func GetDB() *sql.DB {
-	return db
+func GetDB() *DB {
+	return db.Load()
+}
+
+// Driver returns the name of the connected database driver
+func Driver() string {
+	return db.Load().dialect.DriverName()
 }
 
 // Close closes the database connection
 func Close() error {
-	if db != nil {
-		return db.Close()
+	if database := db.Load(); database != nil {
+		return database.Close()
 	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DB wraps a connection pool and rewrites every query through the dialect of its driver,
+// so services can keep writing SQLite-flavoured SQL regardless of the backend
+type DB struct {
+	*sql.DB
+	dialect Dialect
+}
+
+// Rebind returns a query as it is sent to the database
+func (d *DB) Rebind(query string) string {
+	return d.dialect.Rebind(query)
+}
+
+// Query runs a query after rewriting it for the dialect, retrying while the database is busy
+func (d *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return d.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext runs a query after rewriting it for the dialect, retrying while the database is busy
+func (d *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := withRetry(ctx, func() error {
+		var err error
+		rows, err = d.DB.QueryContext(ctx, d.dialect.Rebind(query), args...)
+		return err
+	})
+	return rows, err
+}
+
+// Row is the result of QueryRow. The query runs when Scan is called, so busy errors raised while the row is
+// read can be retried along with the query itself.
+type Row struct {
+	db    *DB
+	ctx   context.Context
+	query string
+	args  []interface{}
+}
+
+// Scan runs the query and copies the first row into dest, retrying the whole read while the database is busy.
+// Like sql.Row it returns sql.ErrNoRows when the query selected nothing.
+func (r *Row) Scan(dest ...interface{}) error {
+	return withRetry(r.ctx, func() error {
+		rows, err := r.db.DB.QueryContext(r.ctx, r.db.dialect.Rebind(r.query), r.args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			return sql.ErrNoRows
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		return rows.Close()
+	})
+}
+
+// QueryRow runs a single-row query after rewriting it for the dialect, retrying while the database is busy
+func (d *DB) QueryRow(query string, args ...interface{}) *Row {
+	return d.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext runs a single-row query after rewriting it for the dialect, retrying while the database is busy
+func (d *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	return &Row{db: d, ctx: ctx, query: query, args: args}
+}
+
+// Exec runs a statement after rewriting it for the dialect, retrying while the database is busy
+func (d *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return d.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext runs a statement after rewriting it for the dialect, retrying while the database is busy
+func (d *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := withRetry(ctx, func() error {
+		var err error
+		result, err = d.DB.ExecContext(ctx, d.dialect.Rebind(query), args...)
+		return err
+	})
+	return result, err
+}
+
+// ExplainQueryPlan returns the engine's plan for a query, one step per entry
+func (d *DB) ExplainQueryPlan(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := d.QueryContext(ctx, d.dialect.ExplainQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query plan failed: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("query plan failed: %w", err)
+	}
+
+	// SQLite returns id, parent, notused, detail; PostgreSQL a single QUERY PLAN column
+	plan := []string{}
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		values[i] = new(interface{})
+	}
+	for rows.Next() {
+		if err := rows.Scan(values...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		detail := *(values[len(values)-1].(*interface{}))
+		if b, ok := detail.([]byte); ok {
+			detail = string(b)
+		}
+		plan = append(plan, fmt.Sprint(detail))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return plan, nil
+}
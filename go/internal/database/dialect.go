@@ -0,0 +1,109 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Supported database drivers, selected with DB_DRIVER
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+// Dialect adapts the SQLite-flavoured SQL written by the services to a database engine
+type Dialect interface {
+	// DriverName is the database/sql driver used to open connections
+	DriverName() string
+	// Rebind rewrites a query written for SQLite into the engine's syntax
+	Rebind(query string) string
+	// ColumnsQuery returns a query listing the column names of a table, one per row
+	ColumnsQuery(table string) string
+	// ExplainQuery returns a query whose rows describe the plan of query, one step per row in the last column
+	ExplainQuery(query string) string
+}
+
+// dialectFor returns the dialect of a supported driver
+func dialectFor(driver string) (Dialect, error) {
+	switch driver {
+	case DriverSQLite:
+		return sqliteDialect{}, nil
+	case DriverPostgres:
+		return postgresDialect{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q; use %s or %s", driver, DriverSQLite, DriverPostgres)
+	}
+}
+
+// sqliteDialect is the native dialect; queries run unchanged
+type sqliteDialect struct{}
+
+func (sqliteDialect) DriverName() string { return DriverSQLite }
+
+func (sqliteDialect) Rebind(query string) string { return query }
+
+func (sqliteDialect) ColumnsQuery(table string) string {
+	return "SELECT name FROM pragma_table_info('" + table + "')"
+}
+
+func (sqliteDialect) ExplainQuery(query string) string {
+	return "EXPLAIN QUERY PLAN " + query
+}
+
+// PostgreSQL has no NOCASE collation, so case-insensitive comparisons are rewritten to ILIKE and lower()
+var (
+	postgresLikeNoCaseRe  = regexp.MustCompile(`([\w.]+) LIKE \? COLLATE NOCASE`)
+	postgresEqualNoCaseRe = regexp.MustCompile(`([\w.]+) = \? COLLATE NOCASE`)
+	postgresInNoCaseRe    = regexp.MustCompile(`([\w.]+) COLLATE NOCASE IN \(([?, ]+)\)`)
+	postgresNoCaseIndexRe = regexp.MustCompile(`\((\w+) COLLATE NOCASE\)`)
+	// Case-sensitive substring searches use SQLite's instr, which PostgreSQL calls strpos
+	postgresInstrRe = regexp.MustCompile(`\binstr\(`)
+)
+
+// postgresDialect rewrites SQLite queries for PostgreSQL
+type postgresDialect struct{}
+
+func (postgresDialect) DriverName() string { return DriverPostgres }
+
+func (postgresDialect) Rebind(query string) string {
+	query = postgresLikeNoCaseRe.ReplaceAllString(query, "$1 ILIKE ?")
+	query = postgresEqualNoCaseRe.ReplaceAllString(query, "lower($1) = lower(?)")
+	query = postgresInNoCaseRe.ReplaceAllStringFunc(query, func(match string) string {
+		parts := postgresInNoCaseRe.FindStringSubmatch(match)
+		placeholders := strings.Split(parts[2], ",")
+		for i := range placeholders {
+			placeholders[i] = "lower(?)"
+		}
+		return "lower(" + parts[1] + ") IN (" + strings.Join(placeholders, ", ") + ")"
+	})
+	// Expression indexes on lower() back the rewritten comparisons
+	query = postgresNoCaseIndexRe.ReplaceAllString(query, "(lower($1))")
+	query = postgresInstrRe.ReplaceAllString(query, "strpos(")
+	return numberPlaceholders(query)
+}
+
+func (postgresDialect) ColumnsQuery(table string) string {
+	return "SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = '" + table + "'"
+}
+
+func (postgresDialect) ExplainQuery(query string) string {
+	return "EXPLAIN " + query
+}
+
+// numberPlaceholders replaces each ? placeholder with PostgreSQL's numbered $1, $2, ... form.
+// The services never put a literal ? inside SQL strings, so no quoting rules are needed.
+func numberPlaceholders(query string) string {
+	var builder strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,58 @@
+package database
+
+import "testing"
+
+func TestPostgresRebind(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			"SELECT id FROM postal_codes WHERE postal_code = ? ORDER BY id LIMIT ?",
+			"SELECT id FROM postal_codes WHERE postal_code = $1 ORDER BY id LIMIT $2",
+		},
+		{
+			"WHERE 1=1 AND city_clean LIKE ? COLLATE NOCASE AND street = ? COLLATE NOCASE",
+			"WHERE 1=1 AND city_clean ILIKE $1 AND lower(street) = lower($2)",
+		},
+		{
+			"WHERE (city_normalized LIKE ? COLLATE NOCASE OR street_normalized LIKE ? COLLATE NOCASE)",
+			"WHERE (city_normalized ILIKE $1 OR street_normalized ILIKE $2)",
+		},
+		{
+			"WHERE county IS NOT NULL AND province COLLATE NOCASE IN (?, ?, ?) LIMIT ?",
+			"WHERE county IS NOT NULL AND lower(province) IN (lower($1), lower($2), lower($3)) LIMIT $4",
+		},
+		{
+			"CREATE INDEX IF NOT EXISTS idx_city ON postal_codes(city COLLATE NOCASE)",
+			"CREATE INDEX IF NOT EXISTS idx_city ON postal_codes(lower(city))",
+		},
+		{
+			"WHERE 1=1 AND instr(city_clean, ?) = 1 AND instr(street, ?) > 0 AND province = ?",
+			"WHERE 1=1 AND strpos(city_clean, $1) = 1 AND strpos(street, $2) > 0 AND province = $3",
+		},
+		{
+			"SELECT id FROM postal_codes ORDER BY RANDOM() LIMIT ?",
+			"SELECT id FROM postal_codes ORDER BY RANDOM() LIMIT $1",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := (postgresDialect{}).Rebind(tt.query); got != tt.expected {
+			t.Errorf("Rebind(%q)\n got: %q\nwant: %q", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestSQLiteRebindLeavesQueriesUnchanged(t *testing.T) {
+	query := "SELECT id FROM postal_codes WHERE city LIKE ? COLLATE NOCASE LIMIT ?"
+	if got := (sqliteDialect{}).Rebind(query); got != query {
+		t.Errorf("Rebind(%q) = %q", query, got)
+	}
+}
+
+func TestDialectForRejectsUnknownDrivers(t *testing.T) {
+	if _, err := dialectFor("mysql"); err == nil {
+		t.Error("expected an error for an unsupported driver")
+	}
+}
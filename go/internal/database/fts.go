@@ -0,0 +1,68 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// FTSTable is the FTS5 index over the normalized postal_codes city and street names
+const FTSTable = "postal_codes_fts"
+
+// hasFTS is set when the FTS5 index exists and the SQLite driver can query it
+var hasFTS atomic.Bool
+
+// HasFTS reports whether full-text queries can use the FTS5 index
+func HasFTS() bool {
+	return hasFTS.Load()
+}
+
+// detectFTS checks that the FTS5 index exists and is usable. The mattn/go-sqlite3 driver
+// only ships FTS5 when built with -tags sqlite_fts5, so an existing table may still be unreadable.
+// Other backends never have it.
+func detectFTS(database *DB) bool {
+	if database.dialect.DriverName() != DriverSQLite {
+		return false
+	}
+
+	var name string
+	err := database.QueryRow("SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?", FTSTable).Scan(&name)
+	if err != nil {
+		return false
+	}
+
+	_, err = database.Exec("SELECT rowid FROM " + FTSTable + " LIMIT 1")
+	return err == nil
+}
+
+// BuildFTSIndex creates and fills the FTS5 index. It is an external-content table over postal_codes,
+// so only the token index is stored. It indexes the Polish-normalized columns because the unicode61
+// tokenizer does not fold letters such as "ł" that have no Unicode decomposition.
+// Building writes to the database file and takes a few seconds on the full dataset.
+func BuildFTSIndex() error {
+	if Driver() != DriverSQLite {
+		return fmt.Errorf("full-text index requires SQLite, not %s", Driver())
+	}
+
+	statements := []string{
+		"CREATE VIRTUAL TABLE IF NOT EXISTS " + FTSTable + " USING fts5(city_normalized, street_normalized, content='postal_codes', content_rowid='id', tokenize='unicode61 remove_diacritics 2')",
+		"INSERT INTO " + FTSTable + "(" + FTSTable + ") VALUES('rebuild')",
+	}
+	for _, statement := range statements {
+		if _, err := GetDB().Exec(statement); err != nil {
+			return fmt.Errorf("failed to build full-text index: %w", err)
+		}
+	}
+
+	hasFTS.Store(true)
+	return nil
+}
+
+// PostalCodeColumnsFor returns PostalCodeColumns qualified with a table alias, for use in joins
+func PostalCodeColumnsFor(alias string) string {
+	columns := strings.Split(PostalCodeColumns(), ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
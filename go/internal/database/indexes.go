@@ -0,0 +1,37 @@
+package database
+
+import "fmt"
+
+// searchIndexes are the indexes behind the commonly filtered columns. Names match the ones created
+// by helpers/create_db.py, so databases built by it are left untouched.
+var searchIndexes = []string{
+	"CREATE INDEX IF NOT EXISTS idx_postal_code ON postal_codes(postal_code)",
+	"CREATE INDEX IF NOT EXISTS idx_city ON postal_codes(city COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_city_clean ON postal_codes(city_clean COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_city_normalized ON postal_codes(city_normalized COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_street ON postal_codes(street COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_street_normalized ON postal_codes(street_normalized COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_province ON postal_codes(province COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_county ON postal_codes(county COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_municipality ON postal_codes(municipality COLLATE NOCASE)",
+	"CREATE INDEX IF NOT EXISTS idx_house_numbers ON postal_codes(house_numbers)",
+	"CREATE INDEX IF NOT EXISTS idx_population ON postal_codes(population DESC)",
+}
+
+// coordinateIndex speeds up bounding box and nearest searches on databases with coordinates
+const coordinateIndex = "CREATE INDEX IF NOT EXISTS idx_coordinates ON postal_codes(latitude, longitude)"
+
+// EnsureIndexes creates any missing search indexes. It writes to the database file, so read-only
+// deployments should disable it with SKIP_INDEX_CREATION.
+func EnsureIndexes() error {
+	statements := append([]string{}, searchIndexes...)
+	if hasCoordinates.Load() {
+		statements = append(statements, coordinateIndex)
+	}
+	for _, statement := range statements {
+		if _, err := GetDB().Exec(statement); err != nil {
+			return fmt.Errorf("failed to create index: %w", err)
+		}
+	}
+	return nil
+}
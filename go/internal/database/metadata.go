@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Sources of Metadata.GeneratedAt
+const (
+	MetadataSourceTable = "metadata_table"
+	MetadataSourceFile  = "file_mtime"
+)
+
+// Metadata describes how fresh the loaded dataset is
+type Metadata struct {
+	DataVersion string `json:"data_version,omitempty"`
+	GeneratedAt string `json:"generated_at,omitempty"`
+	// Source tells where GeneratedAt came from; empty when it is unknown
+	Source string `json:"source,omitempty"`
+}
+
+// LoadMetadata reads data_version and generated_at from an optional metadata(key, value) table. Without the
+// table or a generated_at entry, generated_at falls back to the modification time of the SQLite file, and stays
+// empty for other drivers.
+func LoadMetadata(ctx context.Context) (Metadata, error) {
+	database := GetDB()
+	if database == nil {
+		return Metadata{}, errors.New("database not initialized")
+	}
+
+	values, err := readMetadataTable(ctx, database)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	metadata := Metadata{DataVersion: values["data_version"], GeneratedAt: values["generated_at"]}
+	if metadata.GeneratedAt != "" {
+		metadata.Source = MetadataSourceTable
+		return metadata, nil
+	}
+
+	healthMu.Lock()
+	path := openedPath
+	healthMu.Unlock()
+	if path == "" {
+		return metadata, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("database file unavailable: %w", err)
+	}
+	metadata.GeneratedAt = info.ModTime().UTC().Format(time.RFC3339)
+	metadata.Source = MetadataSourceFile
+	return metadata, nil
+}
+
+// readMetadataTable returns the key/value pairs of the metadata table, or nil when the database has none
+func readMetadataTable(ctx context.Context, database *DB) (map[string]string, error) {
+	rows, err := database.QueryContext(ctx, database.dialect.ColumnsQuery("metadata"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect database schema: %w", err)
+	}
+	columns := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		columns[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	if !columns["key"] || !columns["value"] {
+		return nil, nil
+	}
+
+	rows, err = database.QueryContext(ctx, "SELECT key, value FROM metadata")
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	values := map[string]string{}
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		values[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return values, nil
+}
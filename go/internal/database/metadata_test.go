@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadMetadataFallsBackToFileModificationTime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "postal_codes.db")
+	createDatabaseFile(t, path)
+	modified := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatalf("failed to set modification time: %v", err)
+	}
+	if err := InitializeWithPath(path); err != nil {
+		t.Fatalf("InitializeWithPath failed: %v", err)
+	}
+	t.Cleanup(func() { Close() })
+
+	metadata, err := LoadMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	expected := Metadata{GeneratedAt: "2025-03-01T12:00:00Z", Source: MetadataSourceFile}
+	if metadata != expected {
+		t.Errorf("expected %+v, got %+v", expected, metadata)
+	}
+}
+
+func TestLoadMetadataReadsMetadataTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "postal_codes.db")
+	createDatabaseFile(t, path)
+	if err := InitializeWithPath(path); err != nil {
+		t.Fatalf("InitializeWithPath failed: %v", err)
+	}
+	t.Cleanup(func() { Close() })
+	_, err := GetDB().Exec(`CREATE TABLE metadata (key TEXT PRIMARY KEY, value TEXT);
+		INSERT INTO metadata VALUES ('data_version', '2025.04'), ('generated_at', '2025-04-02T08:30:00Z')`)
+	if err != nil {
+		t.Fatalf("failed to create metadata table: %v", err)
+	}
+
+	metadata, err := LoadMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	expected := Metadata{DataVersion: "2025.04", GeneratedAt: "2025-04-02T08:30:00Z", Source: MetadataSourceTable}
+	if metadata != expected {
+		t.Errorf("expected %+v, got %+v", expected, metadata)
+	}
+}
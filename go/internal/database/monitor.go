@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pingTimeout bounds each health check ping
+const pingTimeout = 2 * time.Second
+
+// Reconnect attempts wait reconnectInitialBackoff, doubling up to reconnectMaxBackoff
+const (
+	reconnectInitialBackoff = time.Second
+	reconnectMaxBackoff     = 30 * time.Second
+)
+
+// ConnectionHealth is the state of the database connection as seen by the health monitor
+type ConnectionHealth struct {
+	Healthy    bool      `json:"healthy"`
+	CheckedAt  time.Time `json:"checked_at"`
+	Error      string    `json:"error,omitempty"`
+	Reconnects int       `json:"reconnects"`
+}
+
+var (
+	healthMu sync.Mutex
+	health   ConnectionHealth
+	// openedPath and openedFile identify the SQLite file behind the connection, so a file replaced on disk is noticed
+	openedPath string
+	openedFile os.FileInfo
+)
+
+// reopen connects again after a failed health check; tests replace it
+var reopen = Reload
+
+// reloadHooks run after the monitor reopened the database, e.g. to drop results cached from the previous file
+var (
+	reloadHooksMu sync.Mutex
+	reloadHooks   []func()
+)
+
+// OnReload registers fn to run each time the health monitor reconnects to the database
+func OnReload(fn func()) {
+	reloadHooksMu.Lock()
+	defer reloadHooksMu.Unlock()
+	reloadHooks = append(reloadHooks, fn)
+}
+
+// runReloadHooks calls the registered reload hooks in registration order
+func runReloadHooks() {
+	reloadHooksMu.Lock()
+	hooks := reloadHooks
+	reloadHooksMu.Unlock()
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// Health returns the result of the latest connection check
+func Health() ConnectionHealth {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	return health
+}
+
+// markHealthy records a working connection
+func markHealthy() {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	health.Healthy = true
+	health.CheckedAt = time.Now()
+	health.Error = ""
+}
+
+// markUnhealthy records why the connection is not usable
+func markUnhealthy(err error) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	health.Healthy = false
+	health.CheckedAt = time.Now()
+	health.Error = err.Error()
+}
+
+// rememberDatabaseFile records the file opened by a SQLite connection; other drivers have none
+func rememberDatabaseFile(dialect Dialect, dsn string) {
+	var path string
+	var info os.FileInfo
+	if dialect.DriverName() == DriverSQLite {
+		path, _, _ = strings.Cut(dsn, "?")
+		if stat, err := os.Stat(path); err == nil {
+			info = stat
+		}
+	}
+
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	openedPath, openedFile = path, info
+}
+
+// checkConnection pings the database and, for SQLite, verifies that the file on disk is still the one
+// that was opened. A replaced file keeps answering pings through the old file handle.
+func checkConnection(ctx context.Context) error {
+	database := GetDB()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := database.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	healthMu.Lock()
+	path, opened := openedPath, openedFile
+	healthMu.Unlock()
+	if opened == nil {
+		return nil
+	}
+	current, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("database file unavailable: %w", err)
+	}
+	if !os.SameFile(opened, current) {
+		return errors.New("database file was replaced")
+	}
+	return nil
+}
+
+// MonitorConnection checks the connection every interval until ctx is done. When a check fails it
+// reconnects through Initialize, retrying with backoff, so a database file can be swapped without a restart.
+func MonitorConnection(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := checkConnection(ctx); err != nil {
+			markUnhealthy(err)
+			log.Printf("Database connection unhealthy, reconnecting: %v", err)
+			reconnect(ctx)
+			continue
+		}
+		markHealthy()
+	}
+}
+
+// reconnect reopens the database until it succeeds or ctx is done
+func reconnect(ctx context.Context) {
+	backoff := reconnectInitialBackoff
+	for {
+		err := reopen()
+		if err == nil {
+			healthMu.Lock()
+			health.Reconnects++
+			healthMu.Unlock()
+			runReloadHooks()
+			log.Printf("Database reconnected")
+			return
+		}
+
+		markUnhealthy(err)
+		log.Printf("Database reconnect failed, retrying in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, reconnectMaxBackoff)
+	}
+}
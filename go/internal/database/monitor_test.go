@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// createDatabaseFile writes an empty SQLite database with a postal_codes table
+func createDatabaseFile(t *testing.T, path string) {
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Exec("CREATE TABLE postal_codes (id INTEGER PRIMARY KEY, postal_code TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+}
+
+func TestMonitorReconnectsWhenDatabaseFileIsReplaced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "postal_codes.db")
+	createDatabaseFile(t, path)
+	if err := InitializeWithPath(path); err != nil {
+		t.Fatalf("InitializeWithPath failed: %v", err)
+	}
+	t.Cleanup(func() { Close() })
+
+	if err := checkConnection(context.Background()); err != nil {
+		t.Fatalf("expected a healthy connection, got %v", err)
+	}
+
+	// Swap in a new file the way a data update does
+	replacement := filepath.Join(dir, "replacement.db")
+	createDatabaseFile(t, replacement)
+	if err := os.Rename(replacement, path); err != nil {
+		t.Fatalf("failed to replace database: %v", err)
+	}
+	if err := checkConnection(context.Background()); err == nil {
+		t.Fatal("expected the replaced file to be detected")
+	}
+
+	previousReopen := reopen
+	reopen = func() error { return InitializeWithPath(path) }
+	t.Cleanup(func() { reopen = previousReopen })
+	previousHooks := reloadHooks
+	t.Cleanup(func() { reloadHooks = previousHooks })
+	reloaded := make(chan struct{}, 1)
+	OnReload(func() { reloaded <- struct{}{} })
+	before := Health().Reconnects
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go MonitorConnection(ctx, 5*time.Millisecond)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for Health().Reconnects == before {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a reconnect, health is %+v", Health())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := checkConnection(context.Background()); err != nil {
+		t.Errorf("expected the reopened connection to be healthy, got %v", err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Error("expected the reload hooks to run after the reconnect")
+	}
+}
+
+func TestReopenLetsInFlightQueriesFinish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "postal_codes.db")
+	createDatabaseFile(t, path)
+	if err := InitializeWithPath(path); err != nil {
+		t.Fatalf("InitializeWithPath failed: %v", err)
+	}
+	t.Cleanup(func() { Close() })
+	if _, err := GetDB().Exec("INSERT INTO postal_codes (postal_code) VALUES ('00-001'), ('00-002'), ('00-003')"); err != nil {
+		t.Fatalf("failed to insert rows: %v", err)
+	}
+
+	old := GetDB()
+	rows, err := old.Query("SELECT postal_code FROM postal_codes ORDER BY id")
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if err := InitializeWithPath(path); err != nil {
+		t.Fatalf("InitializeWithPath failed: %v", err)
+	}
+	if GetDB() == old {
+		t.Fatal("expected a new connection")
+	}
+	time.Sleep(3 * idlePollInterval)
+	if err := old.Ping(); err != nil {
+		t.Fatalf("expected the old connection to stay open while a query reads rows, got %v", err)
+	}
+
+	read := 0
+	for rows.Next() {
+		read++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || read != 3 {
+		t.Fatalf("expected the in-flight query to read 3 rows, got %d (%v)", read, err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for old.Ping() == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the old connection to be closed once idle")
+		}
+		time.Sleep(idlePollInterval)
+	}
+}
+
+func TestReopenWaitsForExportsBeyondTheQueryTimeout(t *testing.T) {
+	t.Setenv("QUERY_TIMEOUT_MS", "10")
+	t.Setenv("EXPORT_TIMEOUT_MS", "2000")
+	path := filepath.Join(t.TempDir(), "postal_codes.db")
+	createDatabaseFile(t, path)
+	if err := InitializeWithPath(path); err != nil {
+		t.Fatalf("InitializeWithPath failed: %v", err)
+	}
+	t.Cleanup(func() { Close() })
+	if _, err := GetDB().Exec("INSERT INTO postal_codes (postal_code) VALUES ('00-001'), ('00-002')"); err != nil {
+		t.Fatalf("failed to insert rows: %v", err)
+	}
+
+	old := GetDB()
+	rows, err := old.Query("SELECT postal_code FROM postal_codes ORDER BY id")
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if err := InitializeWithPath(path); err != nil {
+		t.Fatalf("InitializeWithPath failed: %v", err)
+	}
+
+	// A slow export keeps reading long after the query timeout has passed
+	time.Sleep(10 * idlePollInterval)
+	if err := old.Ping(); err != nil {
+		t.Fatalf("expected the old connection to stay open for the export timeout, got %v", err)
+	}
+	read := 0
+	for rows.Next() {
+		read++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil || read != 2 {
+		t.Fatalf("expected the export to read 2 rows, got %d (%v)", read, err)
+	}
+}
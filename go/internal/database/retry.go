@@ -0,0 +1,42 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"postal-api/internal/config"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// isBusy reports whether err is SQLite's transient "database is locked" or "database table is locked" error
+func isBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+}
+
+// withRetry runs fn up to DB_RETRY_ATTEMPTS times while it fails with a busy error, waiting
+// DB_RETRY_BACKOFF_MS before the first retry and doubling the wait after each one. Other errors
+// and a cancelled context end the loop immediately.
+func withRetry(ctx context.Context, fn func() error) error {
+	attempts := config.DBRetryAttempts()
+	backoff := config.DBRetryBackoff()
+
+	err := fn()
+	for attempt := 1; attempt < attempts && isBusy(err); attempt++ {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+		err = fn()
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// openLockedDatabase creates a temporary SQLite database held under an exclusive lock by a second
+// connection. It returns a DB that fails immediately instead of waiting on the lock, and a function
+// releasing the lock.
+func openLockedDatabase(t *testing.T) (*DB, func()) {
+	path := filepath.Join(t.TempDir(), "busy.db")
+	locker, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { locker.Close() })
+	if _, err := locker.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	conn, err := locker.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	if _, err := conn.ExecContext(context.Background(), "BEGIN EXCLUSIVE"); err != nil {
+		t.Fatalf("failed to lock database: %v", err)
+	}
+	release := func() {
+		conn.ExecContext(context.Background(), "COMMIT")
+		conn.Close()
+	}
+	t.Cleanup(release)
+
+	busy, err := sql.Open("sqlite3", path+"?_busy_timeout=0")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { busy.Close() })
+	return &DB{DB: busy, dialect: sqliteDialect{}}, release
+}
+
+func TestExecRetriesWhileDatabaseIsLocked(t *testing.T) {
+	t.Setenv("DB_RETRY_ATTEMPTS", "6")
+	t.Setenv("DB_RETRY_BACKOFF_MS", "10")
+	database, release := openLockedDatabase(t)
+
+	time.AfterFunc(50*time.Millisecond, release)
+	if _, err := database.Exec("INSERT INTO items (id) VALUES (?)", 1); err != nil {
+		t.Fatalf("expected the insert to succeed once the lock was released, got %v", err)
+	}
+}
+
+func TestQueryRowRetriesWhileDatabaseIsLocked(t *testing.T) {
+	t.Setenv("DB_RETRY_ATTEMPTS", "6")
+	t.Setenv("DB_RETRY_BACKOFF_MS", "10")
+	database, release := openLockedDatabase(t)
+
+	time.AfterFunc(50*time.Millisecond, release)
+	var count int
+	if err := database.QueryRow("SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+		t.Fatalf("expected the query to succeed once the lock was released, got %v", err)
+	}
+
+	if err := database.QueryRow("SELECT id FROM items").Scan(&count); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows for an empty result, got %v", err)
+	}
+}
+
+func TestExecWithoutRetriesReportsLockedDatabase(t *testing.T) {
+	t.Setenv("DB_RETRY_ATTEMPTS", "1")
+	database, _ := openLockedDatabase(t)
+
+	_, err := database.Exec("INSERT INTO items (id) VALUES (?)", 1)
+	if !isBusy(err) {
+		t.Fatalf("expected a busy error, got %v", err)
+	}
+}
+
+func TestWithRetryOnlyRetriesBusyErrors(t *testing.T) {
+	t.Setenv("DB_RETRY_ATTEMPTS", "3")
+	t.Setenv("DB_RETRY_BACKOFF_MS", "1")
+
+	tests := []struct {
+		name     string
+		err      error
+		attempts int
+	}{
+		{"busy", sqlite3.Error{Code: sqlite3.ErrBusy}, 3},
+		{"locked", sqlite3.Error{Code: sqlite3.ErrLocked}, 3},
+		{"constraint", sqlite3.Error{Code: sqlite3.ErrConstraint}, 1},
+		{"other", errors.New("connection lost"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := withRetry(context.Background(), func() error {
+				attempts++
+				return tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected the last error to be returned, got %v", err)
+			}
+			if attempts != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, attempts)
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsWhenContextIsCancelled(t *testing.T) {
+	t.Setenv("DB_RETRY_ATTEMPTS", "5")
+	t.Setenv("DB_RETRY_BACKOFF_MS", "1000")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := withRetry(ctx, func() error {
+		attempts++
+		return sqlite3.Error{Code: sqlite3.ErrBusy}
+	})
+	if !isBusy(err) || attempts != 1 {
+		t.Errorf("expected one busy attempt, got %d attempts and %v", attempts, err)
+	}
+}
@@ -0,0 +1,56 @@
+package postalpb
+
+import (
+	"postal-api/internal/database"
+	"postal-api/internal/services"
+)
+
+// FromSearchResponse converts a search response to its protobuf message, shared by the gRPC API and the
+// protobuf encoding of the REST API
+func FromSearchResponse(response *services.SearchResponse) *SearchResponse {
+	message := &SearchResponse{
+		Results:                 make([]*PostalCode, 0, len(response.Results)),
+		Count:                   int32(response.Count),
+		SearchType:              response.SearchType,
+		Message:                 response.Message,
+		FallbackUsed:            response.FallbackUsed,
+		FallbackLevel:           int32(response.FallbackLevel),
+		PolishNormalizationUsed: response.PolishNormalizationUsed,
+		Exact:                   response.Exact,
+		LimitClamped:            response.LimitClamped,
+		Suggestions:             response.Suggestions,
+		FilteredByProvince:      response.FilteredByProvince,
+		FilteredByCounty:        response.FilteredByCounty,
+	}
+	for _, record := range response.Results {
+		message.Results = append(message.Results, fromPostalCode(record))
+	}
+	if params := response.NormalizedParams; params != nil {
+		message.NormalizedParams = &NormalizedParams{
+			City:         params.City,
+			Street:       params.Street,
+			HouseNumber:  params.HouseNumber,
+			Province:     params.Province,
+			County:       params.County,
+			Municipality: params.Municipality,
+		}
+	}
+	return message
+}
+
+// fromPostalCode converts a postal code record to its protobuf message
+func fromPostalCode(record database.PostalCode) *PostalCode {
+	return &PostalCode{
+		PostalCode:   record.PostalCode,
+		City:         record.City,
+		Street:       record.Street,
+		HouseNumbers: record.HouseNumbers,
+		Municipality: record.Municipality,
+		County:       record.County,
+		Province:     record.Province,
+		MatchedRange: record.MatchedRange,
+		MatchQuality: record.MatchQuality,
+		Latitude:     record.Latitude,
+		Longitude:    record.Longitude,
+	}
+}
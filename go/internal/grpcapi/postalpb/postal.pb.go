@@ -0,0 +1,1021 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: postal/v1/postal.proto
+
+package postalpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PostalCode is one postal code record; optional fields are unset where the JSON API omits them
+type PostalCode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostalCode    string                 `protobuf:"bytes,1,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Street        *string                `protobuf:"bytes,3,opt,name=street,proto3,oneof" json:"street,omitempty"`
+	HouseNumbers  *string                `protobuf:"bytes,4,opt,name=house_numbers,json=houseNumbers,proto3,oneof" json:"house_numbers,omitempty"`
+	Municipality  *string                `protobuf:"bytes,5,opt,name=municipality,proto3,oneof" json:"municipality,omitempty"`
+	County        *string                `protobuf:"bytes,6,opt,name=county,proto3,oneof" json:"county,omitempty"`
+	Province      string                 `protobuf:"bytes,7,opt,name=province,proto3" json:"province,omitempty"`
+	MatchedRange  *string                `protobuf:"bytes,8,opt,name=matched_range,json=matchedRange,proto3,oneof" json:"matched_range,omitempty"`
+	MatchQuality  string                 `protobuf:"bytes,9,opt,name=match_quality,json=matchQuality,proto3" json:"match_quality,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,10,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,11,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostalCode) Reset() {
+	*x = PostalCode{}
+	mi := &file_postal_v1_postal_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostalCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostalCode) ProtoMessage() {}
+
+func (x *PostalCode) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostalCode.ProtoReflect.Descriptor instead.
+func (*PostalCode) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PostalCode) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *PostalCode) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *PostalCode) GetStreet() string {
+	if x != nil && x.Street != nil {
+		return *x.Street
+	}
+	return ""
+}
+
+func (x *PostalCode) GetHouseNumbers() string {
+	if x != nil && x.HouseNumbers != nil {
+		return *x.HouseNumbers
+	}
+	return ""
+}
+
+func (x *PostalCode) GetMunicipality() string {
+	if x != nil && x.Municipality != nil {
+		return *x.Municipality
+	}
+	return ""
+}
+
+func (x *PostalCode) GetCounty() string {
+	if x != nil && x.County != nil {
+		return *x.County
+	}
+	return ""
+}
+
+func (x *PostalCode) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *PostalCode) GetMatchedRange() string {
+	if x != nil && x.MatchedRange != nil {
+		return *x.MatchedRange
+	}
+	return ""
+}
+
+func (x *PostalCode) GetMatchQuality() string {
+	if x != nil {
+		return x.MatchQuality
+	}
+	return ""
+}
+
+func (x *PostalCode) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *PostalCode) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+// SearchRequest carries the query parameters of GET /postal-codes
+type SearchRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	City        string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Street      string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	HouseNumber string                 `protobuf:"bytes,3,opt,name=house_number,json=houseNumber,proto3" json:"house_number,omitempty"`
+	// province and county accept comma-separated lists like the REST filters
+	Province     string `protobuf:"bytes,4,opt,name=province,proto3" json:"province,omitempty"`
+	County       string `protobuf:"bytes,5,opt,name=county,proto3" json:"county,omitempty"`
+	Municipality string `protobuf:"bytes,6,opt,name=municipality,proto3" json:"municipality,omitempty"`
+	// limit defaults to the server's search limit when 0
+	Limit              int32  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	Exact              bool   `protobuf:"varint,8,opt,name=exact,proto3" json:"exact,omitempty"`
+	Sort               string `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`
+	Side               string `protobuf:"bytes,10,opt,name=side,proto3" json:"side,omitempty"`
+	Fuzzy              bool   `protobuf:"varint,11,opt,name=fuzzy,proto3" json:"fuzzy,omitempty"`
+	Loose              bool   `protobuf:"varint,12,opt,name=loose,proto3" json:"loose,omitempty"`
+	CaseSensitive      bool   `protobuf:"varint,13,opt,name=case_sensitive,json=caseSensitive,proto3" json:"case_sensitive,omitempty"`
+	AssumeAllWhenEmpty bool   `protobuf:"varint,14,opt,name=assume_all_when_empty,json=assumeAllWhenEmpty,proto3" json:"assume_all_when_empty,omitempty"`
+	// normalize and fallback default to the server defaults when unset
+	Normalize     *bool `protobuf:"varint,15,opt,name=normalize,proto3,oneof" json:"normalize,omitempty"`
+	Fallback      *bool `protobuf:"varint,16,opt,name=fallback,proto3,oneof" json:"fallback,omitempty"`
+	Phonetic      bool  `protobuf:"varint,17,opt,name=phonetic,proto3" json:"phonetic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_postal_v1_postal_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetHouseNumber() string {
+	if x != nil {
+		return x.HouseNumber
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetMunicipality() string {
+	if x != nil {
+		return x.Municipality
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+func (x *SearchRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetFuzzy() bool {
+	if x != nil {
+		return x.Fuzzy
+	}
+	return false
+}
+
+func (x *SearchRequest) GetLoose() bool {
+	if x != nil {
+		return x.Loose
+	}
+	return false
+}
+
+func (x *SearchRequest) GetCaseSensitive() bool {
+	if x != nil {
+		return x.CaseSensitive
+	}
+	return false
+}
+
+func (x *SearchRequest) GetAssumeAllWhenEmpty() bool {
+	if x != nil {
+		return x.AssumeAllWhenEmpty
+	}
+	return false
+}
+
+func (x *SearchRequest) GetNormalize() bool {
+	if x != nil && x.Normalize != nil {
+		return *x.Normalize
+	}
+	return false
+}
+
+func (x *SearchRequest) GetFallback() bool {
+	if x != nil && x.Fallback != nil {
+		return *x.Fallback
+	}
+	return false
+}
+
+func (x *SearchRequest) GetPhonetic() bool {
+	if x != nil {
+		return x.Phonetic
+	}
+	return false
+}
+
+// NormalizedParams echoes the Polish-normalized filters the normalization tiers searched with
+type NormalizedParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          *string                `protobuf:"bytes,1,opt,name=city,proto3,oneof" json:"city,omitempty"`
+	Street        *string                `protobuf:"bytes,2,opt,name=street,proto3,oneof" json:"street,omitempty"`
+	HouseNumber   *string                `protobuf:"bytes,3,opt,name=house_number,json=houseNumber,proto3,oneof" json:"house_number,omitempty"`
+	Province      *string                `protobuf:"bytes,4,opt,name=province,proto3,oneof" json:"province,omitempty"`
+	County        *string                `protobuf:"bytes,5,opt,name=county,proto3,oneof" json:"county,omitempty"`
+	Municipality  *string                `protobuf:"bytes,6,opt,name=municipality,proto3,oneof" json:"municipality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NormalizedParams) Reset() {
+	*x = NormalizedParams{}
+	mi := &file_postal_v1_postal_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NormalizedParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NormalizedParams) ProtoMessage() {}
+
+func (x *NormalizedParams) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NormalizedParams.ProtoReflect.Descriptor instead.
+func (*NormalizedParams) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NormalizedParams) GetCity() string {
+	if x != nil && x.City != nil {
+		return *x.City
+	}
+	return ""
+}
+
+func (x *NormalizedParams) GetStreet() string {
+	if x != nil && x.Street != nil {
+		return *x.Street
+	}
+	return ""
+}
+
+func (x *NormalizedParams) GetHouseNumber() string {
+	if x != nil && x.HouseNumber != nil {
+		return *x.HouseNumber
+	}
+	return ""
+}
+
+func (x *NormalizedParams) GetProvince() string {
+	if x != nil && x.Province != nil {
+		return *x.Province
+	}
+	return ""
+}
+
+func (x *NormalizedParams) GetCounty() string {
+	if x != nil && x.County != nil {
+		return *x.County
+	}
+	return ""
+}
+
+func (x *NormalizedParams) GetMunicipality() string {
+	if x != nil && x.Municipality != nil {
+		return *x.Municipality
+	}
+	return ""
+}
+
+// SearchResponse mirrors the JSON search response
+type SearchResponse struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Results                 []*PostalCode          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Count                   int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	SearchType              string                 `protobuf:"bytes,3,opt,name=search_type,json=searchType,proto3" json:"search_type,omitempty"`
+	Message                 string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	FallbackUsed            bool                   `protobuf:"varint,5,opt,name=fallback_used,json=fallbackUsed,proto3" json:"fallback_used,omitempty"`
+	FallbackLevel           int32                  `protobuf:"varint,6,opt,name=fallback_level,json=fallbackLevel,proto3" json:"fallback_level,omitempty"`
+	PolishNormalizationUsed bool                   `protobuf:"varint,7,opt,name=polish_normalization_used,json=polishNormalizationUsed,proto3" json:"polish_normalization_used,omitempty"`
+	Exact                   bool                   `protobuf:"varint,8,opt,name=exact,proto3" json:"exact,omitempty"`
+	LimitClamped            bool                   `protobuf:"varint,9,opt,name=limit_clamped,json=limitClamped,proto3" json:"limit_clamped,omitempty"`
+	Suggestions             []string               `protobuf:"bytes,10,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	FilteredByProvince      []string               `protobuf:"bytes,11,rep,name=filtered_by_province,json=filteredByProvince,proto3" json:"filtered_by_province,omitempty"`
+	FilteredByCounty        []string               `protobuf:"bytes,12,rep,name=filtered_by_county,json=filteredByCounty,proto3" json:"filtered_by_county,omitempty"`
+	NormalizedParams        *NormalizedParams      `protobuf:"bytes,13,opt,name=normalized_params,json=normalizedParams,proto3" json:"normalized_params,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_postal_v1_postal_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchResponse) GetResults() []*PostalCode {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetSearchType() string {
+	if x != nil {
+		return x.SearchType
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetFallbackUsed() bool {
+	if x != nil {
+		return x.FallbackUsed
+	}
+	return false
+}
+
+func (x *SearchResponse) GetFallbackLevel() int32 {
+	if x != nil {
+		return x.FallbackLevel
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetPolishNormalizationUsed() bool {
+	if x != nil {
+		return x.PolishNormalizationUsed
+	}
+	return false
+}
+
+func (x *SearchResponse) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+func (x *SearchResponse) GetLimitClamped() bool {
+	if x != nil {
+		return x.LimitClamped
+	}
+	return false
+}
+
+func (x *SearchResponse) GetSuggestions() []string {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetFilteredByProvince() []string {
+	if x != nil {
+		return x.FilteredByProvince
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetFilteredByCounty() []string {
+	if x != nil {
+		return x.FilteredByCounty
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetNormalizedParams() *NormalizedParams {
+	if x != nil {
+		return x.NormalizedParams
+	}
+	return nil
+}
+
+// GetByCodeRequest names a postal code, with or without the dash
+type GetByCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PostalCode    string                 `protobuf:"bytes,1,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByCodeRequest) Reset() {
+	*x = GetByCodeRequest{}
+	mi := &file_postal_v1_postal_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByCodeRequest) ProtoMessage() {}
+
+func (x *GetByCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetByCodeRequest) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetByCodeRequest) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+// ListProvincesRequest filters the province list
+type ListProvincesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// contains matches the prefix anywhere in a name instead of only at its start
+	Contains      bool `protobuf:"varint,2,opt,name=contains,proto3" json:"contains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvincesRequest) Reset() {
+	*x = ListProvincesRequest{}
+	mi := &file_postal_v1_postal_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvincesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvincesRequest) ProtoMessage() {}
+
+func (x *ListProvincesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvincesRequest.ProtoReflect.Descriptor instead.
+func (*ListProvincesRequest) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListProvincesRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListProvincesRequest) GetContains() bool {
+	if x != nil {
+		return x.Contains
+	}
+	return false
+}
+
+// ListLocationsRequest filters and pages the county, municipality, city and street lists.
+// Filters a list does not support are ignored, like unknown REST query parameters.
+type ListLocationsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Province     string                 `protobuf:"bytes,1,opt,name=province,proto3" json:"province,omitempty"`
+	County       string                 `protobuf:"bytes,2,opt,name=county,proto3" json:"county,omitempty"`
+	Municipality string                 `protobuf:"bytes,3,opt,name=municipality,proto3" json:"municipality,omitempty"`
+	City         string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Prefix       string                 `protobuf:"bytes,5,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Contains     bool                   `protobuf:"varint,6,opt,name=contains,proto3" json:"contains,omitempty"`
+	// limit defaults to the location list page size when 0
+	Limit         int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocationsRequest) Reset() {
+	*x = ListLocationsRequest{}
+	mi := &file_postal_v1_postal_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocationsRequest) ProtoMessage() {}
+
+func (x *ListLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocationsRequest.ProtoReflect.Descriptor instead.
+func (*ListLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListLocationsRequest) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *ListLocationsRequest) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *ListLocationsRequest) GetMunicipality() string {
+	if x != nil {
+		return x.Municipality
+	}
+	return ""
+}
+
+func (x *ListLocationsRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ListLocationsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListLocationsRequest) GetContains() bool {
+	if x != nil {
+		return x.Contains
+	}
+	return false
+}
+
+func (x *ListLocationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListLocationsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// LocationList is one page of location names
+type LocationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocationList) Reset() {
+	*x = LocationList{}
+	mi := &file_postal_v1_postal_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationList) ProtoMessage() {}
+
+func (x *LocationList) ProtoReflect() protoreflect.Message {
+	mi := &file_postal_v1_postal_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationList.ProtoReflect.Descriptor instead.
+func (*LocationList) Descriptor() ([]byte, []int) {
+	return file_postal_v1_postal_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LocationList) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *LocationList) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LocationList) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *LocationList) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *LocationList) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *LocationList) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_postal_v1_postal_proto protoreflect.FileDescriptor
+
+const file_postal_v1_postal_proto_rawDesc = "" +
+	"\n" +
+	"\x16postal/v1/postal.proto\x12\tpostal.v1\"\xe3\x03\n" +
+	"\n" +
+	"PostalCode\x12\x1f\n" +
+	"\vpostal_code\x18\x01 \x01(\tR\n" +
+	"postalCode\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x1b\n" +
+	"\x06street\x18\x03 \x01(\tH\x00R\x06street\x88\x01\x01\x12(\n" +
+	"\rhouse_numbers\x18\x04 \x01(\tH\x01R\fhouseNumbers\x88\x01\x01\x12'\n" +
+	"\fmunicipality\x18\x05 \x01(\tH\x02R\fmunicipality\x88\x01\x01\x12\x1b\n" +
+	"\x06county\x18\x06 \x01(\tH\x03R\x06county\x88\x01\x01\x12\x1a\n" +
+	"\bprovince\x18\a \x01(\tR\bprovince\x12(\n" +
+	"\rmatched_range\x18\b \x01(\tH\x04R\fmatchedRange\x88\x01\x01\x12#\n" +
+	"\rmatch_quality\x18\t \x01(\tR\fmatchQuality\x12\x1f\n" +
+	"\blatitude\x18\n" +
+	" \x01(\x01H\x05R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\v \x01(\x01H\x06R\tlongitude\x88\x01\x01B\t\n" +
+	"\a_streetB\x10\n" +
+	"\x0e_house_numbersB\x0f\n" +
+	"\r_municipalityB\t\n" +
+	"\a_countyB\x10\n" +
+	"\x0e_matched_rangeB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"\x8b\x04\n" +
+	"\rSearchRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x16\n" +
+	"\x06street\x18\x02 \x01(\tR\x06street\x12!\n" +
+	"\fhouse_number\x18\x03 \x01(\tR\vhouseNumber\x12\x1a\n" +
+	"\bprovince\x18\x04 \x01(\tR\bprovince\x12\x16\n" +
+	"\x06county\x18\x05 \x01(\tR\x06county\x12\"\n" +
+	"\fmunicipality\x18\x06 \x01(\tR\fmunicipality\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05exact\x18\b \x01(\bR\x05exact\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sort\x12\x12\n" +
+	"\x04side\x18\n" +
+	" \x01(\tR\x04side\x12\x14\n" +
+	"\x05fuzzy\x18\v \x01(\bR\x05fuzzy\x12\x14\n" +
+	"\x05loose\x18\f \x01(\bR\x05loose\x12%\n" +
+	"\x0ecase_sensitive\x18\r \x01(\bR\rcaseSensitive\x121\n" +
+	"\x15assume_all_when_empty\x18\x0e \x01(\bR\x12assumeAllWhenEmpty\x12!\n" +
+	"\tnormalize\x18\x0f \x01(\bH\x00R\tnormalize\x88\x01\x01\x12\x1f\n" +
+	"\bfallback\x18\x10 \x01(\bH\x01R\bfallback\x88\x01\x01\x12\x1a\n" +
+	"\bphonetic\x18\x11 \x01(\bR\bphoneticB\f\n" +
+	"\n" +
+	"_normalizeB\v\n" +
+	"\t_fallback\"\xa5\x02\n" +
+	"\x10NormalizedParams\x12\x17\n" +
+	"\x04city\x18\x01 \x01(\tH\x00R\x04city\x88\x01\x01\x12\x1b\n" +
+	"\x06street\x18\x02 \x01(\tH\x01R\x06street\x88\x01\x01\x12&\n" +
+	"\fhouse_number\x18\x03 \x01(\tH\x02R\vhouseNumber\x88\x01\x01\x12\x1f\n" +
+	"\bprovince\x18\x04 \x01(\tH\x03R\bprovince\x88\x01\x01\x12\x1b\n" +
+	"\x06county\x18\x05 \x01(\tH\x04R\x06county\x88\x01\x01\x12'\n" +
+	"\fmunicipality\x18\x06 \x01(\tH\x05R\fmunicipality\x88\x01\x01B\a\n" +
+	"\x05_cityB\t\n" +
+	"\a_streetB\x0f\n" +
+	"\r_house_numberB\v\n" +
+	"\t_provinceB\t\n" +
+	"\a_countyB\x0f\n" +
+	"\r_municipality\"\xa1\x04\n" +
+	"\x0eSearchResponse\x12/\n" +
+	"\aresults\x18\x01 \x03(\v2\x15.postal.v1.PostalCodeR\aresults\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vsearch_type\x18\x03 \x01(\tR\n" +
+	"searchType\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12#\n" +
+	"\rfallback_used\x18\x05 \x01(\bR\ffallbackUsed\x12%\n" +
+	"\x0efallback_level\x18\x06 \x01(\x05R\rfallbackLevel\x12:\n" +
+	"\x19polish_normalization_used\x18\a \x01(\bR\x17polishNormalizationUsed\x12\x14\n" +
+	"\x05exact\x18\b \x01(\bR\x05exact\x12#\n" +
+	"\rlimit_clamped\x18\t \x01(\bR\flimitClamped\x12 \n" +
+	"\vsuggestions\x18\n" +
+	" \x03(\tR\vsuggestions\x120\n" +
+	"\x14filtered_by_province\x18\v \x03(\tR\x12filteredByProvince\x12,\n" +
+	"\x12filtered_by_county\x18\f \x03(\tR\x10filteredByCounty\x12H\n" +
+	"\x11normalized_params\x18\r \x01(\v2\x1b.postal.v1.NormalizedParamsR\x10normalizedParams\"3\n" +
+	"\x10GetByCodeRequest\x12\x1f\n" +
+	"\vpostal_code\x18\x01 \x01(\tR\n" +
+	"postalCode\"J\n" +
+	"\x14ListProvincesRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1a\n" +
+	"\bcontains\x18\x02 \x01(\bR\bcontains\"\xe4\x01\n" +
+	"\x14ListLocationsRequest\x12\x1a\n" +
+	"\bprovince\x18\x01 \x01(\tR\bprovince\x12\x16\n" +
+	"\x06county\x18\x02 \x01(\tR\x06county\x12\"\n" +
+	"\fmunicipality\x18\x03 \x01(\tR\fmunicipality\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12\x16\n" +
+	"\x06prefix\x18\x05 \x01(\tR\x06prefix\x12\x1a\n" +
+	"\bcontains\x18\x06 \x01(\bR\bcontains\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\b \x01(\x05R\x06offset\"\x98\x01\n" +
+	"\fLocationList\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage2\x89\x04\n" +
+	"\rPostalService\x12=\n" +
+	"\x06Search\x12\x18.postal.v1.SearchRequest\x1a\x19.postal.v1.SearchResponse\x12C\n" +
+	"\tGetByCode\x12\x1b.postal.v1.GetByCodeRequest\x1a\x19.postal.v1.SearchResponse\x12I\n" +
+	"\rListProvinces\x12\x1f.postal.v1.ListProvincesRequest\x1a\x17.postal.v1.LocationList\x12H\n" +
+	"\fListCounties\x12\x1f.postal.v1.ListLocationsRequest\x1a\x17.postal.v1.LocationList\x12N\n" +
+	"\x12ListMunicipalities\x12\x1f.postal.v1.ListLocationsRequest\x1a\x17.postal.v1.LocationList\x12F\n" +
+	"\n" +
+	"ListCities\x12\x1f.postal.v1.ListLocationsRequest\x1a\x17.postal.v1.LocationList\x12G\n" +
+	"\vListStreets\x12\x1f.postal.v1.ListLocationsRequest\x1a\x17.postal.v1.LocationListB/Z-postal-api/internal/grpcapi/postalpb;postalpbb\x06proto3"
+
+var (
+	file_postal_v1_postal_proto_rawDescOnce sync.Once
+	file_postal_v1_postal_proto_rawDescData []byte
+)
+
+func file_postal_v1_postal_proto_rawDescGZIP() []byte {
+	file_postal_v1_postal_proto_rawDescOnce.Do(func() {
+		file_postal_v1_postal_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_postal_v1_postal_proto_rawDesc), len(file_postal_v1_postal_proto_rawDesc)))
+	})
+	return file_postal_v1_postal_proto_rawDescData
+}
+
+var file_postal_v1_postal_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_postal_v1_postal_proto_goTypes = []any{
+	(*PostalCode)(nil),           // 0: postal.v1.PostalCode
+	(*SearchRequest)(nil),        // 1: postal.v1.SearchRequest
+	(*NormalizedParams)(nil),     // 2: postal.v1.NormalizedParams
+	(*SearchResponse)(nil),       // 3: postal.v1.SearchResponse
+	(*GetByCodeRequest)(nil),     // 4: postal.v1.GetByCodeRequest
+	(*ListProvincesRequest)(nil), // 5: postal.v1.ListProvincesRequest
+	(*ListLocationsRequest)(nil), // 6: postal.v1.ListLocationsRequest
+	(*LocationList)(nil),         // 7: postal.v1.LocationList
+}
+var file_postal_v1_postal_proto_depIdxs = []int32{
+	0, // 0: postal.v1.SearchResponse.results:type_name -> postal.v1.PostalCode
+	2, // 1: postal.v1.SearchResponse.normalized_params:type_name -> postal.v1.NormalizedParams
+	1, // 2: postal.v1.PostalService.Search:input_type -> postal.v1.SearchRequest
+	4, // 3: postal.v1.PostalService.GetByCode:input_type -> postal.v1.GetByCodeRequest
+	5, // 4: postal.v1.PostalService.ListProvinces:input_type -> postal.v1.ListProvincesRequest
+	6, // 5: postal.v1.PostalService.ListCounties:input_type -> postal.v1.ListLocationsRequest
+	6, // 6: postal.v1.PostalService.ListMunicipalities:input_type -> postal.v1.ListLocationsRequest
+	6, // 7: postal.v1.PostalService.ListCities:input_type -> postal.v1.ListLocationsRequest
+	6, // 8: postal.v1.PostalService.ListStreets:input_type -> postal.v1.ListLocationsRequest
+	3, // 9: postal.v1.PostalService.Search:output_type -> postal.v1.SearchResponse
+	3, // 10: postal.v1.PostalService.GetByCode:output_type -> postal.v1.SearchResponse
+	7, // 11: postal.v1.PostalService.ListProvinces:output_type -> postal.v1.LocationList
+	7, // 12: postal.v1.PostalService.ListCounties:output_type -> postal.v1.LocationList
+	7, // 13: postal.v1.PostalService.ListMunicipalities:output_type -> postal.v1.LocationList
+	7, // 14: postal.v1.PostalService.ListCities:output_type -> postal.v1.LocationList
+	7, // 15: postal.v1.PostalService.ListStreets:output_type -> postal.v1.LocationList
+	9, // [9:16] is the sub-list for method output_type
+	2, // [2:9] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_postal_v1_postal_proto_init() }
+func file_postal_v1_postal_proto_init() {
+	if File_postal_v1_postal_proto != nil {
+		return
+	}
+	file_postal_v1_postal_proto_msgTypes[0].OneofWrappers = []any{}
+	file_postal_v1_postal_proto_msgTypes[1].OneofWrappers = []any{}
+	file_postal_v1_postal_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_postal_v1_postal_proto_rawDesc), len(file_postal_v1_postal_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_postal_v1_postal_proto_goTypes,
+		DependencyIndexes: file_postal_v1_postal_proto_depIdxs,
+		MessageInfos:      file_postal_v1_postal_proto_msgTypes,
+	}.Build()
+	File_postal_v1_postal_proto = out.File
+	file_postal_v1_postal_proto_goTypes = nil
+	file_postal_v1_postal_proto_depIdxs = nil
+}
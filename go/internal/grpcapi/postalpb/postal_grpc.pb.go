@@ -0,0 +1,367 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: postal/v1/postal.proto
+
+package postalpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PostalService_Search_FullMethodName             = "/postal.v1.PostalService/Search"
+	PostalService_GetByCode_FullMethodName          = "/postal.v1.PostalService/GetByCode"
+	PostalService_ListProvinces_FullMethodName      = "/postal.v1.PostalService/ListProvinces"
+	PostalService_ListCounties_FullMethodName       = "/postal.v1.PostalService/ListCounties"
+	PostalService_ListMunicipalities_FullMethodName = "/postal.v1.PostalService/ListMunicipalities"
+	PostalService_ListCities_FullMethodName         = "/postal.v1.PostalService/ListCities"
+	PostalService_ListStreets_FullMethodName        = "/postal.v1.PostalService/ListStreets"
+)
+
+// PostalServiceClient is the client API for PostalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PostalService mirrors the search, lookup and location list endpoints of the REST API
+type PostalServiceClient interface {
+	// Search runs the tiered postal code search of GET /postal-codes
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// GetByCode returns the records of one postal code like GET /postal-codes/{postal_code}
+	GetByCode(ctx context.Context, in *GetByCodeRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// ListProvinces lists provinces like GET /locations/provinces
+	ListProvinces(ctx context.Context, in *ListProvincesRequest, opts ...grpc.CallOption) (*LocationList, error)
+	// ListCounties lists counties like GET /locations/counties
+	ListCounties(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error)
+	// ListMunicipalities lists municipalities like GET /locations/municipalities
+	ListMunicipalities(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error)
+	// ListCities lists cities like GET /locations/cities
+	ListCities(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error)
+	// ListStreets lists streets like GET /locations/streets
+	ListStreets(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error)
+}
+
+type postalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPostalServiceClient(cc grpc.ClientConnInterface) PostalServiceClient {
+	return &postalServiceClient{cc}
+}
+
+func (c *postalServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, PostalService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postalServiceClient) GetByCode(ctx context.Context, in *GetByCodeRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, PostalService_GetByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postalServiceClient) ListProvinces(ctx context.Context, in *ListProvincesRequest, opts ...grpc.CallOption) (*LocationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LocationList)
+	err := c.cc.Invoke(ctx, PostalService_ListProvinces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postalServiceClient) ListCounties(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LocationList)
+	err := c.cc.Invoke(ctx, PostalService_ListCounties_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postalServiceClient) ListMunicipalities(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LocationList)
+	err := c.cc.Invoke(ctx, PostalService_ListMunicipalities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postalServiceClient) ListCities(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LocationList)
+	err := c.cc.Invoke(ctx, PostalService_ListCities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *postalServiceClient) ListStreets(ctx context.Context, in *ListLocationsRequest, opts ...grpc.CallOption) (*LocationList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LocationList)
+	err := c.cc.Invoke(ctx, PostalService_ListStreets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PostalServiceServer is the server API for PostalService service.
+// All implementations must embed UnimplementedPostalServiceServer
+// for forward compatibility.
+//
+// PostalService mirrors the search, lookup and location list endpoints of the REST API
+type PostalServiceServer interface {
+	// Search runs the tiered postal code search of GET /postal-codes
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// GetByCode returns the records of one postal code like GET /postal-codes/{postal_code}
+	GetByCode(context.Context, *GetByCodeRequest) (*SearchResponse, error)
+	// ListProvinces lists provinces like GET /locations/provinces
+	ListProvinces(context.Context, *ListProvincesRequest) (*LocationList, error)
+	// ListCounties lists counties like GET /locations/counties
+	ListCounties(context.Context, *ListLocationsRequest) (*LocationList, error)
+	// ListMunicipalities lists municipalities like GET /locations/municipalities
+	ListMunicipalities(context.Context, *ListLocationsRequest) (*LocationList, error)
+	// ListCities lists cities like GET /locations/cities
+	ListCities(context.Context, *ListLocationsRequest) (*LocationList, error)
+	// ListStreets lists streets like GET /locations/streets
+	ListStreets(context.Context, *ListLocationsRequest) (*LocationList, error)
+	mustEmbedUnimplementedPostalServiceServer()
+}
+
+// UnimplementedPostalServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPostalServiceServer struct{}
+
+func (UnimplementedPostalServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedPostalServiceServer) GetByCode(context.Context, *GetByCodeRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByCode not implemented")
+}
+func (UnimplementedPostalServiceServer) ListProvinces(context.Context, *ListProvincesRequest) (*LocationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProvinces not implemented")
+}
+func (UnimplementedPostalServiceServer) ListCounties(context.Context, *ListLocationsRequest) (*LocationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCounties not implemented")
+}
+func (UnimplementedPostalServiceServer) ListMunicipalities(context.Context, *ListLocationsRequest) (*LocationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMunicipalities not implemented")
+}
+func (UnimplementedPostalServiceServer) ListCities(context.Context, *ListLocationsRequest) (*LocationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCities not implemented")
+}
+func (UnimplementedPostalServiceServer) ListStreets(context.Context, *ListLocationsRequest) (*LocationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStreets not implemented")
+}
+func (UnimplementedPostalServiceServer) mustEmbedUnimplementedPostalServiceServer() {}
+func (UnimplementedPostalServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafePostalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PostalServiceServer will
+// result in compilation errors.
+type UnsafePostalServiceServer interface {
+	mustEmbedUnimplementedPostalServiceServer()
+}
+
+func RegisterPostalServiceServer(s grpc.ServiceRegistrar, srv PostalServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPostalServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PostalService_ServiceDesc, srv)
+}
+
+func _PostalService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostalServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostalService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostalServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostalService_GetByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostalServiceServer).GetByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostalService_GetByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostalServiceServer).GetByCode(ctx, req.(*GetByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostalService_ListProvinces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvincesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostalServiceServer).ListProvinces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostalService_ListProvinces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostalServiceServer).ListProvinces(ctx, req.(*ListProvincesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostalService_ListCounties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostalServiceServer).ListCounties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostalService_ListCounties_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostalServiceServer).ListCounties(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostalService_ListMunicipalities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostalServiceServer).ListMunicipalities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostalService_ListMunicipalities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostalServiceServer).ListMunicipalities(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostalService_ListCities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostalServiceServer).ListCities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostalService_ListCities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostalServiceServer).ListCities(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PostalService_ListStreets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PostalServiceServer).ListStreets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PostalService_ListStreets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PostalServiceServer).ListStreets(ctx, req.(*ListLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PostalService_ServiceDesc is the grpc.ServiceDesc for PostalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PostalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "postal.v1.PostalService",
+	HandlerType: (*PostalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _PostalService_Search_Handler,
+		},
+		{
+			MethodName: "GetByCode",
+			Handler:    _PostalService_GetByCode_Handler,
+		},
+		{
+			MethodName: "ListProvinces",
+			Handler:    _PostalService_ListProvinces_Handler,
+		},
+		{
+			MethodName: "ListCounties",
+			Handler:    _PostalService_ListCounties_Handler,
+		},
+		{
+			MethodName: "ListMunicipalities",
+			Handler:    _PostalService_ListMunicipalities_Handler,
+		},
+		{
+			MethodName: "ListCities",
+			Handler:    _PostalService_ListCities_Handler,
+		},
+		{
+			MethodName: "ListStreets",
+			Handler:    _PostalService_ListStreets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "postal/v1/postal.proto",
+}
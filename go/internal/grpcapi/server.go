@@ -0,0 +1,295 @@
+// Package grpcapi serves the search, lookup and location list endpoints over gRPC, backed by the same services as the REST API
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=postalpb --go_opt=paths=source_relative --go-grpc_out=postalpb --go-grpc_opt=paths=source_relative postal/v1/postal.proto
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+	"strings"
+
+	"postal-api/internal/auth"
+	"postal-api/internal/config"
+	"postal-api/internal/grpcapi/postalpb"
+	"postal-api/internal/i18n"
+	"postal-api/internal/request"
+	"postal-api/internal/services"
+	"postal-api/internal/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyMetadata carries the client's API key, like the X-API-Key header of the REST API
+const apiKeyMetadata = "x-api-key"
+
+// server implements postalpb.PostalServiceServer on top of the services package
+type server struct {
+	postalpb.UnimplementedPostalServiceServer
+}
+
+// NewServer creates a gRPC server with the postal service registered. Calls need one of apiKeys, or the admin key,
+// in the x-api-key metadata unless apiKeys is empty, and each call is bounded by the configured query timeout.
+// A panicking call fails with Internal instead of taking the process down.
+func NewServer(apiKeys []string) *grpc.Server {
+	keys := auth.WithAdminKey(apiKeys, config.AdminAPIKey())
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(recoveryInterceptor, unaryInterceptor(keys)))
+	postalpb.RegisterPostalServiceServer(grpcServer, &server{})
+	return grpcServer
+}
+
+// recoveryInterceptor turns a panic in a call into an Internal error, like gin.Recovery does for the REST API
+func recoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("gRPC %s panicked: %v\n%s", info.FullMethod, recovered, debug.Stack())
+			resp, err = nil, status.Error(codes.Internal, "internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// unaryInterceptor checks the API key, counts its use and applies the query timeout before a call reaches its handler
+func unaryInterceptor(apiKeys []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if len(apiKeys) > 0 {
+			key := lastValue(metadata.ValueFromIncomingContext(ctx, apiKeyMetadata))
+			if !auth.ValidKey(apiKeys, key) {
+				return nil, status.Error(codes.Unauthenticated, "missing or invalid API key")
+			}
+			auth.Usage.Record(key)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, config.QueryTimeout())
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// lastValue returns the last of the metadata values sent under one key, or "" when there are none
+func lastValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// invalidArgument reports the rejected parameters of a request in a single InvalidArgument status
+func invalidArgument(fieldErrors []request.FieldError) error {
+	reasons := make([]string, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		reasons[i] = fieldError.Field + " " + i18n.Translate(i18n.DefaultLanguage, fieldError.MessageID, fieldError.Args...)
+	}
+	return status.Error(codes.InvalidArgument, strings.Join(reasons, "; "))
+}
+
+// serviceError maps a service failure to a gRPC status, hiding internal details from the client
+func serviceError(method string, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "database query timed out")
+	}
+	log.Printf("gRPC %s failed: %v", method, err)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// Search runs the tiered postal code search with the validation rules of GET /postal-codes
+func (s *server) Search(ctx context.Context, req *postalpb.SearchRequest) (*postalpb.SearchResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = config.SearchLimit()
+	}
+
+	normalize := !config.PolishNormalizationDisabled()
+	if req.Normalize != nil {
+		normalize = req.GetNormalize()
+	}
+	fallback := true
+	if req.Fallback != nil {
+		fallback = req.GetFallback()
+	}
+
+	params, limitClamped, fieldErrors := request.Search{
+		City:         req.GetCity(),
+		Street:       req.GetStreet(),
+		HouseNumber:  req.GetHouseNumber(),
+		Municipality: req.GetMunicipality(),
+		Province:     req.GetProvince(),
+		County:       req.GetCounty(),
+		Sort:         req.GetSort(),
+		Side:         req.GetSide(),
+		Limit:        limit,
+
+		Exact:              req.GetExact(),
+		Fuzzy:              req.GetFuzzy(),
+		Phonetic:           req.GetPhonetic(),
+		Loose:              req.GetLoose(),
+		CaseSensitive:      req.GetCaseSensitive(),
+		AssumeAllWhenEmpty: req.GetAssumeAllWhenEmpty(),
+		SkipNormalization:  !normalize,
+		SkipFallback:       !fallback,
+	}.Params()
+	if len(fieldErrors) > 0 {
+		return nil, invalidArgument(fieldErrors)
+	}
+
+	response, err := services.SearchPostalCodes(ctx, params)
+	if err != nil {
+		return nil, serviceError("Search", err)
+	}
+	response.LimitClamped = limitClamped
+	return postalpb.FromSearchResponse(response), nil
+}
+
+// GetByCode returns the records of one postal code, accepting dashless codes such as 00001
+func (s *server) GetByCode(ctx context.Context, req *postalpb.GetByCodeRequest) (*postalpb.SearchResponse, error) {
+	postalCode, ok := utils.NormalizePostalCode(req.GetPostalCode())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "postal_code must have the format XX-XXX")
+	}
+
+	response, err := services.GetPostalCodeByCode(ctx, postalCode)
+	if err != nil {
+		return nil, serviceError("GetByCode", err)
+	}
+	if response == nil {
+		return nil, status.Error(codes.NotFound, "postal code not found")
+	}
+	return postalpb.FromSearchResponse(response), nil
+}
+
+// ListProvinces lists the provinces, optionally filtered by a name prefix; the list is never paged
+func (s *server) ListProvinces(ctx context.Context, req *postalpb.ListProvincesRequest) (*postalpb.LocationList, error) {
+	response, err := services.GetProvinces(ctx, request.Optional(strings.TrimSpace(req.GetPrefix())), req.GetContains())
+	if err != nil {
+		return nil, serviceError("ListProvinces", err)
+	}
+	return &postalpb.LocationList{
+		Names: response.Provinces,
+		Count: int32(response.Count),
+		Total: int32(response.Count),
+	}, nil
+}
+
+// ListCounties lists the counties, optionally filtered by province and name prefix
+func (s *server) ListCounties(ctx context.Context, req *postalpb.ListLocationsRequest) (*postalpb.LocationList, error) {
+	opts, err := listOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	province, _, err := listFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	response, err := services.GetCounties(ctx, province, request.Optional(strings.TrimSpace(req.GetPrefix())), opts)
+	if err != nil {
+		return nil, serviceError("ListCounties", err)
+	}
+	return locationList(response.Counties, response.Count, response.Total, response.Limit, response.Offset, response.Message), nil
+}
+
+// ListMunicipalities lists the municipalities, optionally filtered by province, county and name prefix
+func (s *server) ListMunicipalities(ctx context.Context, req *postalpb.ListLocationsRequest) (*postalpb.LocationList, error) {
+	opts, err := listOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	province, county, err := listFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	response, err := services.GetMunicipalities(ctx, province, county, request.Optional(strings.TrimSpace(req.GetPrefix())), opts)
+	if err != nil {
+		return nil, serviceError("ListMunicipalities", err)
+	}
+	return locationList(response.Municipalities, response.Count, response.Total, response.Limit, response.Offset, response.Message), nil
+}
+
+// ListCities lists the cities, optionally filtered by province, county, municipality and name prefix
+func (s *server) ListCities(ctx context.Context, req *postalpb.ListLocationsRequest) (*postalpb.LocationList, error) {
+	opts, err := listOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	province, county, err := listFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	response, err := services.GetCities(ctx, province, county, request.Optional(strings.TrimSpace(req.GetMunicipality())), request.Optional(strings.TrimSpace(req.GetPrefix())), opts)
+	if err != nil {
+		return nil, serviceError("ListCities", err)
+	}
+	return locationList(response.Cities, response.Count, response.Total, response.Limit, response.Offset, response.Message), nil
+}
+
+// ListStreets lists the streets, optionally filtered by city, province, county, municipality and name prefix
+func (s *server) ListStreets(ctx context.Context, req *postalpb.ListLocationsRequest) (*postalpb.LocationList, error) {
+	opts, err := listOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	province, county, err := listFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	response, err := services.GetStreets(ctx, request.Optional(strings.TrimSpace(req.GetCity())), province, county, request.Optional(strings.TrimSpace(req.GetMunicipality())), request.Optional(utils.StripStreetPrefix(req.GetPrefix())), nil, opts)
+	if err != nil {
+		return nil, serviceError("ListStreets", err)
+	}
+	return locationList(response.Streets, response.Count, response.Total, response.Limit, response.Offset, ""), nil
+}
+
+// listOptions validates the paging of a location list request, applying the REST defaults and maximum
+func listOptions(req *postalpb.ListLocationsRequest) (services.ListOptions, error) {
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return services.ListOptions{}, status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = config.DefaultLocationListLimit
+	}
+	return services.ListOptions{
+		Limit:    min(limit, config.MaxLocationListLimit()),
+		Offset:   int(req.GetOffset()),
+		Contains: req.GetContains(),
+	}, nil
+}
+
+// locationList builds a LocationList page
+func locationList(names []string, count, total, limit, offset int, message string) *postalpb.LocationList {
+	return &postalpb.LocationList{
+		Names:   names,
+		Count:   int32(count),
+		Total:   int32(total),
+		Limit:   int32(limit),
+		Offset:  int32(offset),
+		Message: message,
+	}
+}
+
+// listFilters normalizes the comma-separated province and county filters of a location list request,
+// rejecting too many values like the REST API
+func listFilters(req *postalpb.ListLocationsRequest) (province, county *string, err error) {
+	var fieldErrors []request.FieldError
+	filter := func(field, value string) *string {
+		normalized, ok := request.ListFilter(value)
+		if !ok {
+			fieldErrors = append(fieldErrors, request.FieldError{Field: field, MessageID: i18n.MsgMaxListValues, Args: []interface{}{request.MaxListFilterValues}})
+		}
+		return request.Optional(normalized)
+	}
+	province, county = filter("province", req.GetProvince()), filter("county", req.GetCounty())
+	if len(fieldErrors) > 0 {
+		return nil, nil, invalidArgument(fieldErrors)
+	}
+	return province, county, nil
+}
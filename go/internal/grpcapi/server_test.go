@@ -0,0 +1,155 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"postal-api/internal/auth"
+	"postal-api/internal/database"
+	"postal-api/internal/grpcapi/postalpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testDBPath points at the shared database in the project root
+const testDBPath = "../../../postal_codes.db"
+
+// newTestClient serves the postal service over an in-memory connection and returns a client for it
+func newTestClient(t *testing.T, apiKeys []string) postalpb.PostalServiceClient {
+	if _, err := os.Stat(testDBPath); err != nil {
+		t.Skip("Database file postal_codes.db not found")
+	}
+	if err := database.InitializeWithPath(testDBPath); err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := NewServer(apiKeys)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return postalpb.NewPostalServiceClient(conn)
+}
+
+func TestSearchAndLookupMirrorREST(t *testing.T) {
+	client := newTestClient(t, nil)
+	ctx := context.Background()
+
+	search, err := client.Search(ctx, &postalpb.SearchRequest{City: "Kraków", Street: "Floriańska", Limit: 5})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if search.GetCount() == 0 || int(search.GetCount()) != len(search.GetResults()) {
+		t.Fatalf("expected matching results and count, got %d results with count %d", len(search.GetResults()), search.GetCount())
+	}
+	for _, result := range search.GetResults() {
+		if result.GetStreet() != "Floriańska" || result.GetProvince() != "małopolskie" {
+			t.Errorf("unexpected result %v", result)
+		}
+	}
+
+	lookup, err := client.GetByCode(ctx, &postalpb.GetByCodeRequest{PostalCode: "31146"})
+	if err != nil {
+		t.Fatalf("GetByCode failed: %v", err)
+	}
+	if lookup.GetCount() == 0 || lookup.GetResults()[0].GetPostalCode() != "31-146" {
+		t.Errorf("expected records of 31-146, got %v", lookup.GetResults())
+	}
+
+	errorCodes := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{"search without location", func() error {
+			_, err := client.Search(ctx, &postalpb.SearchRequest{Province: "mazowieckie"})
+			return err
+		}, codes.InvalidArgument},
+		{"malformed postal code", func() error {
+			_, err := client.GetByCode(ctx, &postalpb.GetByCodeRequest{PostalCode: "abc"})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown postal code", func() error {
+			_, err := client.GetByCode(ctx, &postalpb.GetByCodeRequest{PostalCode: "99-999"})
+			return err
+		}, codes.NotFound},
+	}
+	for _, tt := range errorCodes {
+		if code := status.Code(tt.call()); code != tt.code {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.code, code)
+		}
+	}
+}
+
+func TestListLocationsPagesLikeREST(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	cities, err := client.ListCities(context.Background(), &postalpb.ListLocationsRequest{Province: "małopolskie", Limit: 3, Offset: 1})
+	if err != nil {
+		t.Fatalf("ListCities failed: %v", err)
+	}
+	if len(cities.GetNames()) != 3 || cities.GetCount() != 3 || cities.GetLimit() != 3 || cities.GetOffset() != 1 {
+		t.Errorf("expected a page of 3 cities at offset 1, got %v", cities)
+	}
+	if cities.GetTotal() <= 3 {
+		t.Errorf("expected the total to exceed the page, got %d", cities.GetTotal())
+	}
+}
+
+func TestAPIKeyRequiredWhenConfigured(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "admin-secret")
+	previous := auth.Usage
+	auth.Usage = auth.NewUsageCounter()
+	t.Cleanup(func() { auth.Usage = previous })
+	client := newTestClient(t, []string{"secret"})
+
+	_, err := client.ListProvinces(context.Background(), &postalpb.ListProvincesRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a key, got %v", err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "secret")
+	provinces, err := client.ListProvinces(ctx, &postalpb.ListProvincesRequest{})
+	if err != nil {
+		t.Fatalf("ListProvinces with key failed: %v", err)
+	}
+	if provinces.GetCount() != 16 {
+		t.Errorf("expected 16 provinces, got %d", provinces.GetCount())
+	}
+
+	// The admin key is accepted everywhere, as in the REST API
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "admin-secret")
+	if _, err := client.ListProvinces(ctx, &postalpb.ListProvincesRequest{}); err != nil {
+		t.Errorf("ListProvinces with the admin key failed: %v", err)
+	}
+
+	usage := auth.Usage.Snapshot(false)
+	if len(usage.Keys) != 2 || usage.Keys[0].Requests != 1 || usage.Keys[1].Requests != 1 {
+		t.Errorf("expected one counted call per valid key, got %+v", usage.Keys)
+	}
+}
+
+func TestRecoveryInterceptorReturnsInternal(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/postal.v1.PostalService/Search"}
+	_, err := recoveryInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal after a panic, got %v", err)
+	}
+}
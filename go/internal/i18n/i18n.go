@@ -0,0 +1,250 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// Supported response languages
+const (
+	English = "en"
+	Polish  = "pl"
+
+	// DefaultLanguage is used when the client asks for nothing we support
+	DefaultLanguage = English
+)
+
+// Message IDs for search fallback notes
+const (
+	MsgHouseNumberNotFound           = "fallback.house_number_not_found"
+	MsgStreetNotFound                = "fallback.street_not_found"
+	MsgStreetWithHouseNumberNotFound = "fallback.street_with_house_number_not_found"
+	MsgLocationStreet                = "fallback.location_street"
+	MsgLocationCity                  = "fallback.location_city"
+	MsgLocationSuffix                = "fallback.location_suffix"
+	MsgLocationSeparator             = "fallback.location_separator"
+	MsgPolishNormalized              = "fallback.polish_normalized"
+	MsgPolishNormalizedSuffix        = "fallback.polish_normalized_suffix"
+)
+
+// Message IDs for search corrections and explanations of empty results
+const (
+	MsgFuzzyCorrection         = "search.fuzzy_correction"
+	MsgPhoneticCorrection      = "search.phonetic_correction"
+	MsgFullTextUnavailable     = "search.full_text_unavailable"
+	MsgBoundingBoxTruncated    = "search.bounding_box_truncated"
+	MsgNoAdministrativeResults = "search.no_administrative_results"
+	MsgFilterMunicipality      = "search.filter_municipality"
+	MsgFilterCounty            = "search.filter_county"
+	MsgFilterProvince          = "search.filter_province"
+	MsgProvinceNotFound        = "search.province_not_found"
+	MsgCountyNotFound          = "search.county_not_found"
+	MsgCountyOutsideProvince   = "search.county_outside_province"
+	MsgNoCountiesFound         = "search.no_counties_found"
+	MsgNoMunicipalitiesFound   = "search.no_municipalities_found"
+	MsgNoCitiesFound           = "search.no_cities_found"
+)
+
+// Message IDs for error responses
+const (
+	MsgNoPostalCodesWithPrefix = "error.no_postal_codes_with_prefix"
+	MsgStreetNotInCity         = "error.street_not_in_city"
+	MsgInvalidAPIKey           = "error.invalid_api_key"
+	MsgAdminKeyRequired        = "error.admin_key_required"
+)
+
+// Message IDs for parameter validation
+const (
+	MsgInvalidParameters  = "validation.invalid_parameters"
+	MsgRequired           = "validation.required"
+	MsgPositiveInteger    = "validation.positive_integer"
+	MsgNonNegativeInteger = "validation.non_negative_integer"
+	MsgNumber             = "validation.number"
+	MsgOneOf              = "validation.one_of"
+	MsgMaxListValues      = "validation.max_list_values"
+	MsgLocationRequired   = "validation.location_required"
+	MsgInvalidSort        = "validation.invalid_sort"
+	MsgUnknownField       = "validation.unknown_field"
+	MsgPostalCodeFormat   = "validation.postal_code_format"
+	MsgPostalPrefixFormat = "validation.postal_prefix_format"
+	MsgLatitudeRange      = "validation.latitude_range"
+	MsgLongitudeRange     = "validation.longitude_range"
+	MsgLessThan           = "validation.less_than"
+	MsgTreeDepth          = "validation.tree_depth"
+	MsgUnlimitedExport    = "validation.unlimited_export"
+	MsgNotWithProtobuf    = "validation.not_with_protobuf"
+	MsgSideMismatch       = "validation.side_mismatch"
+	MsgNotCombinable      = "validation.not_combinable"
+)
+
+// messages holds the fmt templates of every message ID per language
+var messages = map[string]map[string]string{
+	English: {
+		MsgHouseNumberNotFound:           "House number '%[1]s' not found%[2]s. Showing all results%[2]s.",
+		MsgStreetNotFound:                "Street '%[1]s' not found in %[2]s. Showing all results for %[2]s.",
+		MsgStreetWithHouseNumberNotFound: "Street '%[1]s' with house number '%[2]s' not found in %[3]s. Showing all results for %[3]s.",
+		MsgLocationStreet:                "street '%s'",
+		MsgLocationCity:                  "city '%s'",
+		MsgLocationSuffix:                " in %s",
+		MsgLocationSeparator:             " in ",
+		MsgPolishNormalized:              "Search performed with Polish character normalization.",
+		MsgPolishNormalizedSuffix:        "Polish characters were normalized for search.",
+
+		MsgFuzzyCorrection:         "City '%s' not found, showing results for '%s'.",
+		MsgPhoneticCorrection:      "City '%s' not found, showing results for '%s', which sounds alike.",
+		MsgFullTextUnavailable:     "Full-text index is not available; results come from LIKE matching.",
+		MsgBoundingBoxTruncated:    "More than %[1]d postal codes found in the bounding box. Showing the first %[1]d.",
+		MsgNoAdministrativeResults: "No postal codes found for %s.",
+		MsgFilterMunicipality:      "municipality '%s'",
+		MsgFilterCounty:            "county '%s'",
+		MsgFilterProvince:          "province '%s'",
+		MsgProvinceNotFound:        "Province '%s' does not exist.",
+		MsgCountyNotFound:          "County '%s' does not exist.",
+		MsgCountyOutsideProvince:   "County '%s' does not belong to province '%s'; it is in %s.",
+		MsgNoCountiesFound:         "No counties found for the given filters.",
+		MsgNoMunicipalitiesFound:   "No municipalities found for the given filters.",
+		MsgNoCitiesFound:           "No cities found for the given filters.",
+
+		MsgNoPostalCodesWithPrefix: "No postal codes with this prefix",
+		MsgStreetNotInCity:         "No such street in this city",
+		MsgInvalidAPIKey:           "missing or invalid API key",
+		MsgAdminKeyRequired:        "admin API key required",
+
+		MsgInvalidParameters:  "Invalid parameters: %s",
+		MsgRequired:           "is required",
+		MsgPositiveInteger:    "must be a positive integer",
+		MsgNonNegativeInteger: "must be a non-negative integer",
+		MsgNumber:             "must be a number",
+		MsgOneOf:              "must be one of: %s",
+		MsgMaxListValues:      "accepts at most %d comma-separated values",
+		MsgLocationRequired:   "at least one of city, street, municipality or county is required",
+		MsgInvalidSort:        "must be postal_code, city or street with optional :asc or :desc",
+		MsgUnknownField:       "unknown field '%s'; allowed fields: %s",
+		MsgPostalCodeFormat:   "must have the form NN-NNN or NNNNN, e.g. 00-950",
+		MsgPostalPrefixFormat: "must be exactly two digits, e.g. 31",
+		MsgLatitudeRange:      "must be within [-90, 90]",
+		MsgLongitudeRange:     "must be within [-180, 180]",
+		MsgLessThan:           "must be less than %s",
+		MsgTreeDepth:          "must be 1 (counties), 2 (municipalities) or 3 (cities)",
+		MsgUnlimitedExport:    "may only be 0 for NDJSON exports (Accept: application/x-ndjson) when unlimited export is enabled",
+		MsgNotWithProtobuf:    "is not supported with protobuf responses (Accept: application/x-protobuf)",
+		MsgSideMismatch:       "contradicts house_number '%s', which is not on that side",
+		MsgNotCombinable:      "cannot be combined with %s",
+	},
+	Polish: {
+		MsgHouseNumberNotFound:           "Nie znaleziono numeru domu '%[1]s'%[2]s. Wyświetlono wszystkie wyniki%[2]s.",
+		MsgStreetNotFound:                "Nie znaleziono ulicy '%[1]s' w miejscowości %[2]s. Wyświetlono wszystkie wyniki dla miejscowości %[2]s.",
+		MsgStreetWithHouseNumberNotFound: "Nie znaleziono ulicy '%[1]s' z numerem domu '%[2]s' w miejscowości %[3]s. Wyświetlono wszystkie wyniki dla miejscowości %[3]s.",
+		MsgLocationStreet:                "ulica '%s'",
+		MsgLocationCity:                  "miejscowość '%s'",
+		MsgLocationSuffix:                " (%s)",
+		MsgLocationSeparator:             ", ",
+		MsgPolishNormalized:              "Wyszukiwanie wykonano z normalizacją polskich znaków.",
+		MsgPolishNormalizedSuffix:        "Polskie znaki zostały znormalizowane na potrzeby wyszukiwania.",
+
+		MsgFuzzyCorrection:         "Nie znaleziono miejscowości '%s', wyświetlono wyniki dla '%s'.",
+		MsgPhoneticCorrection:      "Nie znaleziono miejscowości '%s', wyświetlono wyniki dla podobnie brzmiącej '%s'.",
+		MsgFullTextUnavailable:     "Indeks pełnotekstowy jest niedostępny; wyniki pochodzą z dopasowania LIKE.",
+		MsgBoundingBoxTruncated:    "W obszarze znaleziono ponad %[1]d kodów pocztowych. Wyświetlono pierwsze %[1]d.",
+		MsgNoAdministrativeResults: "Nie znaleziono kodów pocztowych dla: %s.",
+		MsgFilterMunicipality:      "gmina '%s'",
+		MsgFilterCounty:            "powiat '%s'",
+		MsgFilterProvince:          "województwo '%s'",
+		MsgProvinceNotFound:        "Województwo '%s' nie istnieje.",
+		MsgCountyNotFound:          "Powiat '%s' nie istnieje.",
+		MsgCountyOutsideProvince:   "Powiat '%s' nie należy do województwa '%s'; leży w: %s.",
+		MsgNoCountiesFound:         "Nie znaleziono powiatów dla podanych filtrów.",
+		MsgNoMunicipalitiesFound:   "Nie znaleziono gmin dla podanych filtrów.",
+		MsgNoCitiesFound:           "Nie znaleziono miejscowości dla podanych filtrów.",
+
+		MsgNoPostalCodesWithPrefix: "Brak kodów pocztowych z tym prefiksem",
+		MsgStreetNotInCity:         "Brak takiej ulicy w tej miejscowości",
+		MsgInvalidAPIKey:           "brak klucza API lub klucz jest nieprawidłowy",
+		MsgAdminKeyRequired:        "wymagany jest administracyjny klucz API",
+
+		MsgInvalidParameters:  "Nieprawidłowe parametry: %s",
+		MsgRequired:           "jest wymagany",
+		MsgPositiveInteger:    "musi być dodatnią liczbą całkowitą",
+		MsgNonNegativeInteger: "musi być nieujemną liczbą całkowitą",
+		MsgNumber:             "musi być liczbą",
+		MsgOneOf:              "musi mieć jedną z wartości: %s",
+		MsgMaxListValues:      "przyjmuje najwyżej %d wartości oddzielonych przecinkami",
+		MsgLocationRequired:   "wymagany jest co najmniej jeden z parametrów city, street, municipality lub county",
+		MsgInvalidSort:        "musi mieć wartość postal_code, city lub street z opcjonalnym :asc lub :desc",
+		MsgUnknownField:       "nieznane pole '%s'; dozwolone pola: %s",
+		MsgPostalCodeFormat:   "musi mieć format NN-NNN lub NNNNN, np. 00-950",
+		MsgPostalPrefixFormat: "musi składać się z dokładnie dwóch cyfr, np. 31",
+		MsgLatitudeRange:      "musi mieścić się w przedziale [-90, 90]",
+		MsgLongitudeRange:     "musi mieścić się w przedziale [-180, 180]",
+		MsgLessThan:           "musi być mniejszy niż %s",
+		MsgTreeDepth:          "musi wynosić 1 (powiaty), 2 (gminy) lub 3 (miejscowości)",
+		MsgUnlimitedExport:    "może wynosić 0 tylko dla eksportu NDJSON (Accept: application/x-ndjson), gdy eksport bez limitu jest włączony",
+		MsgNotWithProtobuf:    "nie jest obsługiwany w odpowiedziach protobuf (Accept: application/x-protobuf)",
+		MsgSideMismatch:       "jest sprzeczny z house_number '%s', który nie leży po tej stronie",
+		MsgNotCombinable:      "nie może być użyty razem z %s",
+	},
+}
+
+// supportedTags lists the languages in preference order; the first one is the fallback
+var supportedTags = []language.Tag{language.English, language.Polish}
+
+// matcher resolves Accept-Language preferences such as "pl-PL,pl;q=0.9" to a supported language
+var matcher = language.NewMatcher(supportedTags)
+
+// ParseAcceptLanguage picks the supported language that best matches an Accept-Language header
+func ParseAcceptLanguage(header string) string {
+	if header == "" {
+		return DefaultLanguage
+	}
+
+	_, index, confidence := matcher.Match(parseTags(header)...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	base, _ := supportedTags[index].Base()
+	return base.String()
+}
+
+// parseTags parses an Accept-Language header, ignoring it entirely when malformed
+func parseTags(header string) []language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
+// Translate formats a message in the given language, falling back to English for unsupported languages
+func Translate(lang, id string, args ...interface{}) string {
+	template, ok := messages[lang][id]
+	if !ok {
+		template, ok = messages[DefaultLanguage][id]
+	}
+	if !ok {
+		return id
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// languageKey is the context key holding the response language
+type languageKey struct{}
+
+// WithLanguage returns a context carrying the response language
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// FromContext returns the response language stored in the context, or the default language
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey{}).(string); ok {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// TranslateContext formats a message in the language stored in the context
+func TranslateContext(ctx context.Context, id string, args ...interface{}) string {
+	return Translate(FromContext(ctx), id, args...)
+}
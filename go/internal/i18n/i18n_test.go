@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := map[string]string{
+		"":                           English,
+		"pl":                         Polish,
+		"pl-PL,pl;q=0.9,en;q=0.8":    Polish,
+		"en-US,en;q=0.9,pl;q=0.5":    English,
+		"de-DE":                      English,
+		"de-DE,pl;q=0.7":             Polish,
+		"this is not a language tag": English,
+	}
+
+	for header, want := range cases {
+		if got := ParseAcceptLanguage(header); got != want {
+			t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestTranslateFallsBackToEnglish(t *testing.T) {
+	if got := Translate("de", MsgRequired); got != "is required" {
+		t.Errorf("expected English fallback, got %q", got)
+	}
+	if got := Translate(Polish, MsgOneOf, "asc, desc"); got != "musi mieć jedną z wartości: asc, desc" {
+		t.Errorf("unexpected Polish message %q", got)
+	}
+	if got := Translate(English, "unknown.id"); got != "unknown.id" {
+		t.Errorf("expected unknown IDs to be returned as is, got %q", got)
+	}
+}
+
+func TestEveryMessageIsTranslated(t *testing.T) {
+	for id := range messages[English] {
+		if _, ok := messages[Polish][id]; !ok {
+			t.Errorf("message %s has no Polish translation", id)
+		}
+	}
+}
+
+func TestTranslateContext(t *testing.T) {
+	ctx := WithLanguage(context.Background(), Polish)
+	if got := TranslateContext(ctx, MsgLocationCity, "Kraków"); got != "miejscowość 'Kraków'" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if got := FromContext(context.Background()); got != DefaultLanguage {
+		t.Errorf("expected default language without a stored one, got %q", got)
+	}
+}
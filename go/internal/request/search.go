@@ -0,0 +1,146 @@
+// Package request validates the parameters shared by the REST and gRPC APIs and builds the service parameters,
+// so both servers accept and reject the same input
+package request
+
+import (
+	"strings"
+
+	"postal-api/internal/config"
+	"postal-api/internal/i18n"
+	"postal-api/internal/services"
+	"postal-api/internal/utils"
+)
+
+// FieldError is a rejected parameter with the i18n message, and its arguments, explaining why
+type FieldError struct {
+	Field     string
+	MessageID string
+	Args      []interface{}
+}
+
+// LocationKey reports a missing location filter. It names no single parameter since any of city, street,
+// municipality or county satisfies the requirement.
+const LocationKey = "location"
+
+// MaxListFilterValues caps how many comma-separated values a province or county filter may hold
+const MaxListFilterValues = 20
+
+// Optional returns nil for empty strings so they are treated as absent filters
+func Optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// ListFilter normalizes a comma-separated filter like "mazowieckie, łódzkie". It reports false when the filter
+// holds more than MaxListFilterValues values.
+func ListFilter(value string) (string, bool) {
+	items := services.SplitListFilter(utils.NormalizeWhitespace(value))
+	if len(items) > MaxListFilterValues {
+		return "", false
+	}
+	for i, item := range items {
+		items[i] = utils.NormalizeWhitespace(item)
+	}
+	return strings.Join(items, ","), true
+}
+
+// Search holds the parameters of a postal code search as sent by the client, before normalization
+type Search struct {
+	City         string
+	Street       string
+	HouseNumber  string
+	Municipality string
+	Province     string
+	County       string
+	Sort         string
+	Side         string
+	GroupBy      string
+	// Limit is the page size after the caller applied its default; 0 streams every match
+	Limit int
+
+	Exact              bool
+	Fuzzy              bool
+	Phonetic           bool
+	Loose              bool
+	CaseSensitive      bool
+	AssumeAllWhenEmpty bool
+	SkipNormalization  bool
+	SkipFallback       bool
+	Dedupe             bool
+}
+
+// Params validates the search and builds its service parameters, returning every invalid field. Limits above
+// MAX_SEARCH_LIMIT are clamped, which clamped reports.
+func (s Search) Params() (params utils.SearchParams, clamped bool, errs []FieldError) {
+	fail := func(field, messageID string, args ...interface{}) {
+		errs = append(errs, FieldError{Field: field, MessageID: messageID, Args: args})
+	}
+
+	city := utils.NormalizeWhitespace(s.City)
+	// Street-type prefixes such as "al." are kept: the search expands them along with the bare name
+	street := utils.NormalizeWhitespace(s.Street)
+	municipality := utils.NormalizeWhitespace(s.Municipality)
+	province, ok := ListFilter(s.Province)
+	if !ok {
+		fail("province", i18n.MsgMaxListValues, MaxListFilterValues)
+	}
+	county, ok := ListFilter(s.County)
+	if !ok {
+		fail("county", i18n.MsgMaxListValues, MaxListFilterValues)
+	}
+	sort := strings.TrimSpace(s.Sort)
+	side := strings.ToLower(strings.TrimSpace(s.Side))
+	groupBy := strings.TrimSpace(s.GroupBy)
+
+	// At least one location filter must be provided (province alone is too broad)
+	if city == "" && street == "" && municipality == "" && county == "" {
+		fail(LocationKey, i18n.MsgLocationRequired)
+	}
+	if err := services.ValidateSortParam(sort); err != nil {
+		fail("sort", i18n.MsgInvalidSort)
+	}
+	houseNumber := strings.TrimSpace(s.HouseNumber)
+	if side != "" && side != utils.SideOdd && side != utils.SideEven {
+		fail("side", i18n.MsgOneOf, "odd, even")
+	} else if side != "" && houseNumber != "" && !utils.IsHouseNumberOnSide(houseNumber, side) {
+		// A house number on the other side could only be answered by unrelated fallback rows
+		fail("side", i18n.MsgSideMismatch, houseNumber)
+	}
+	if groupBy != "" && groupBy != services.GroupByPostalCode {
+		fail("group_by", i18n.MsgOneOf, services.GroupByPostalCode)
+	} else if groupBy != "" && s.Dedupe {
+		fail("group_by", i18n.MsgNotCombinable, "dedupe")
+	}
+
+	limit := s.Limit
+	if maxLimit := config.MaxSearchLimit(); limit > maxLimit {
+		limit = maxLimit
+		clamped = true
+	}
+
+	params = utils.SearchParams{
+		City:         Optional(city),
+		Street:       Optional(street),
+		HouseNumber:  Optional(houseNumber),
+		Province:     Optional(province),
+		County:       Optional(county),
+		Municipality: Optional(municipality),
+		Limit:        limit,
+		Exact:        s.Exact,
+		Sort:         sort,
+		Side:         side,
+		Fuzzy:        s.Fuzzy,
+		Loose:        s.Loose,
+
+		Phonetic:           s.Phonetic,
+		CaseSensitive:      s.CaseSensitive,
+		AssumeAllWhenEmpty: s.AssumeAllWhenEmpty,
+		SkipNormalization:  s.SkipNormalization,
+		SkipFallback:       s.SkipFallback,
+		Dedupe:             s.Dedupe,
+		GroupBy:            groupBy,
+	}
+	return params, clamped, errs
+}
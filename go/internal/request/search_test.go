@@ -0,0 +1,76 @@
+package request
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSearchParamsNormalizesAndClamps(t *testing.T) {
+	t.Setenv("MAX_SEARCH_LIMIT", "50")
+
+	params, clamped, errs := Search{
+		City:     "  Nowa   Wieś ",
+		Street:   "ul.  Długa",
+		Province: "mazowieckie , łódzkie",
+		Side:     " Even ",
+		Limit:    500,
+	}.Params()
+	if len(errs) != 0 {
+		t.Fatalf("expected a valid search, got %+v", errs)
+	}
+	if *params.City != "Nowa Wieś" || *params.Street != "ul. Długa" || *params.Province != "mazowieckie,łódzkie" || params.Side != "even" {
+		t.Errorf("unexpected normalized parameters %+v", params)
+	}
+	if params.County != nil || params.HouseNumber != nil {
+		t.Errorf("expected empty filters to be absent, got %+v", params)
+	}
+	if !clamped || params.Limit != 50 {
+		t.Errorf("expected the limit clamped to 50, got %d (clamped %v)", params.Limit, clamped)
+	}
+}
+
+func TestSearchParamsReportsEveryInvalidField(t *testing.T) {
+	_, _, errs := Search{
+		Province: "mazowieckie",
+		Sort:     "population",
+		Side:     "left",
+		GroupBy:  "city",
+		County:   strings.Repeat("a,", MaxListFilterValues+1),
+	}.Params()
+
+	var fields []string
+	for _, fieldError := range errs {
+		fields = append(fields, fieldError.Field)
+	}
+	if expected := []string{"county", LocationKey, "sort", "side", "group_by"}; !slices.Equal(fields, expected) {
+		t.Errorf("expected errors for %v, got %v", expected, fields)
+	}
+}
+
+func TestSearchParamsRejectsHouseNumberOnTheOtherSide(t *testing.T) {
+	tests := []struct {
+		houseNumber, side string
+		valid             bool
+	}{
+		{"13", "even", false},
+		{"13", "odd", true},
+		{"14a", "even", true},
+		{"14a", "odd", false},
+		{"", "even", true},
+		{"13", "", true},
+	}
+	for _, tt := range tests {
+		_, _, errs := Search{City: "Kraków", HouseNumber: tt.houseNumber, Side: tt.side}.Params()
+		if valid := len(errs) == 0; valid != tt.valid {
+			t.Errorf("house_number=%q&side=%q: expected valid %v, got %+v", tt.houseNumber, tt.side, tt.valid, errs)
+		}
+	}
+}
+
+func TestSearchParamsRejectsGroupByWithDedupe(t *testing.T) {
+	_, _, errs := Search{City: "Kraków", GroupBy: "postal_code", Dedupe: true}.Params()
+	if len(errs) != 1 || errs[0].Field != "group_by" {
+		t.Errorf("expected group_by to be rejected with dedupe, got %+v", errs)
+	}
+}
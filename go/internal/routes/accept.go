@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jsonContentType is the media type of the default JSON responses
+const jsonContentType = "application/json"
+
+// Specificity of the Accept media range that matched a media type
+const (
+	acceptNoMatch = iota
+	acceptAnyType
+	acceptAnySubtype
+	acceptExact
+)
+
+// acceptQuality returns the q-value the Accept header gives a media type and how specific the range it came from
+// was; the most specific range wins ("type/subtype" over "type/*" over "*/*")
+func acceptQuality(header, mediaType string) (float64, int) {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+
+	quality, specificity := 0.0, acceptNoMatch
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		rangeSpecificity := acceptNoMatch
+		switch strings.ToLower(strings.TrimSpace(params[0])) {
+		case mediaType:
+			rangeSpecificity = acceptExact
+		case mainType + "/*":
+			rangeSpecificity = acceptAnySubtype
+		case "*/*":
+			rangeSpecificity = acceptAnyType
+		}
+		if rangeSpecificity <= specificity {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			name, value, _ := strings.Cut(param, "=")
+			if strings.EqualFold(strings.TrimSpace(name), "q") {
+				parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+				if err != nil || parsed < 0 || parsed > 1 {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+		quality, specificity = q, rangeSpecificity
+	}
+	return quality, specificity
+}
+
+// prefersOverJSON reports whether the Accept header names mediaType explicitly with a non-zero q-value at least
+// as high as JSON's, so wildcards alone keep the JSON default
+func prefersOverJSON(c *gin.Context, mediaType string) bool {
+	header := c.GetHeader("Accept")
+	quality, specificity := acceptQuality(header, mediaType)
+	if specificity != acceptExact || quality == 0 {
+		return false
+	}
+	jsonQuality, _ := acceptQuality(header, jsonContentType)
+	return quality >= jsonQuality
+}
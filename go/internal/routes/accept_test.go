@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWantsProtobufHonoursQValues(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/x-protobuf", true},
+		{"Application/X-Protobuf", true},
+		{"application/x-protobuf, application/json", true},
+		{"application/json, application/x-protobuf;q=0", false},
+		{"application/json;q=0.9, application/x-protobuf", true},
+		{"application/json, application/x-protobuf;q=0.5", false},
+		{"application/x-protobuf;q=0.5, application/*;q=0.1", true},
+		{"application/x-protobuf;q=oops", false},
+		{"application/x-protobuf-lite", false},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/postal-codes", nil)
+		c.Request.Header.Set("Accept", tt.accept)
+		if got := wantsProtobuf(c); got != tt.want {
+			t.Errorf("Accept %q: expected %v, got %v", tt.accept, tt.want, got)
+		}
+	}
+}
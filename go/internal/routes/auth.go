@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"net/http"
+
+	"postal-api/internal/auth"
+	"postal-api/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyHeader carries the client's API key
+const apiKeyHeader = "X-API-Key"
+
+// apiKeys are the accepted API keys; authentication is disabled while it is empty
+var apiKeys []string
+
+// SetAPIKeys sets the keys accepted in the X-API-Key header. It must be called before RegisterRoutes.
+func SetAPIKeys(keys []string) {
+	apiKeys = keys
+}
+
+// apiKeyMiddleware rejects requests without a valid X-API-Key header with 401.
+// The /health check stays open so load balancers and orchestrators need no key; the detailed health
+// endpoints below it still require one.
+func apiKeyMiddleware(keys []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(keys) == 0 || c.Request.URL.Path == "/health" {
+			c.Next()
+			return
+		}
+
+		if !auth.ValidKey(keys, c.GetHeader(apiKeyHeader)) {
+			c.Header("WWW-Authenticate", apiKeyHeader)
+			respondError(c, http.StatusUnauthorized, CodeUnauthorized, i18n.TranslateContext(c.Request.Context(), i18n.MsgInvalidAPIKey))
+			c.Abort()
+			return
+		}
+		auth.Usage.Record(c.GetHeader(apiKeyHeader))
+		c.Next()
+	}
+}
+
+// adminKeyMiddleware restricts a route group to requests sending the admin key in the X-API-Key header
+func adminKeyMiddleware(adminKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !auth.ValidKey([]string{adminKey}, c.GetHeader(apiKeyHeader)) {
+			c.Header("WWW-Authenticate", apiKeyHeader)
+			respondError(c, http.StatusUnauthorized, CodeUnauthorized, i18n.TranslateContext(c.Request.Context(), i18n.MsgAdminKeyRequired))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
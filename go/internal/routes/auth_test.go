@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newAuthRouter returns a router with the API key middleware in front of two trivial endpoints
+func newAuthRouter(keys []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(apiKeyMiddleware(keys))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/health/details", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/postal-codes", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []string
+		path   string
+		key    string
+		status int
+	}{
+		{"disabled without keys", nil, "/postal-codes", "", http.StatusOK},
+		{"missing key", []string{"secret", "other"}, "/postal-codes", "", http.StatusUnauthorized},
+		{"wrong key", []string{"secret", "other"}, "/postal-codes", "guess", http.StatusUnauthorized},
+		{"valid key", []string{"secret", "other"}, "/postal-codes", "other", http.StatusOK},
+		{"health is exempt", []string{"secret"}, "/health", "", http.StatusOK},
+		{"health details need a key", []string{"secret"}, "/health/details", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.key != "" {
+				request.Header.Set(apiKeyHeader, tt.key)
+			}
+			recorder := httptest.NewRecorder()
+			newAuthRouter(tt.keys).ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestUnauthorizedResponseIsLocalized(t *testing.T) {
+	SetAPIKeys([]string{"secret"})
+	t.Cleanup(func() { SetAPIKeys(nil) })
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router)
+
+	request := httptest.NewRequest(http.MethodGet, "/postal-codes?city=Kraków", nil)
+	request.Header.Set("Accept-Language", "pl")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	var response ErrorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if recorder.Code != http.StatusUnauthorized || response.Error != "brak klucza API lub klucz jest nieprawidłowy" {
+		t.Errorf("expected a Polish 401, got %d %q", recorder.Code, response.Error)
+	}
+}
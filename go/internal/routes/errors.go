@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Error codes are part of the API contract: clients branch on them, so existing values must not change
+const (
+	CodeValidation       = "VALIDATION"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodeNotImplemented   = "NOT_IMPLEMENTED"
+	CodeTimeout          = "TIMEOUT"
+	CodeInternal         = "INTERNAL"
+)
+
+// ErrorResponse is the JSON body of every error response.
+// Details holds an object for context (e.g. request_id) or a []FieldError list for validation failures.
+type ErrorResponse struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// respondError writes an ErrorResponse with the given status, code and human readable message
+func respondError(c *gin.Context, status int, code, message string) {
+	c.JSON(status, ErrorResponse{Error: message, Code: code})
+}
+
+// respondErrorWithDetails writes an ErrorResponse carrying additional machine readable details
+func respondErrorWithDetails(c *gin.Context, status int, code, message string, details gin.H) {
+	c.JSON(status, ErrorResponse{Error: message, Code: code, Details: details})
+}
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"postal-api/internal/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testDBPath points at the shared database in the project root
+const testDBPath = "../../../postal_codes.db"
+
+func TestLookupAnswersHeadWithoutBody(t *testing.T) {
+	if _, err := os.Stat(testDBPath); err != nil {
+		t.Skip("Database file postal_codes.db not found")
+	}
+	if err := database.InitializeWithPath(testDBPath); err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/postal-codes/31-146", http.StatusOK},
+		{"/postal-codes/31146", http.StatusOK},
+		{"/postal-codes/99-999", http.StatusNotFound},
+		{"/postal-codes/abc", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			response, err := http.Head(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("HEAD request failed: %v", err)
+			}
+			defer response.Body.Close()
+			body, _ := io.ReadAll(response.Body)
+			if response.StatusCode != tt.status || len(body) != 0 {
+				t.Errorf("expected status %d without a body, got %d with %q", tt.status, response.StatusCode, body)
+			}
+
+			get, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("GET request failed: %v", err)
+			}
+			get.Body.Close()
+			if get.StatusCode != response.StatusCode {
+				t.Errorf("expected HEAD to match GET status %d, got %d", get.StatusCode, response.StatusCode)
+			}
+		})
+	}
+}
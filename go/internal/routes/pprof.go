@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http/pprof"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerPprofRoutes serves the standard net/http/pprof handlers under /debug/pprof
+func registerPprofRoutes(router *gin.Engine) {
+	group := router.Group("/debug/pprof")
+	group.GET("/", gin.WrapF(pprof.Index))
+	group.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+	group.GET("/profile", gin.WrapF(pprof.Profile))
+	group.GET("/symbol", gin.WrapF(pprof.Symbol))
+	group.POST("/symbol", gin.WrapF(pprof.Symbol))
+	group.GET("/trace", gin.WrapF(pprof.Trace))
+	// Index serves the named runtime profiles: heap, goroutine, allocs, block, mutex, threadcreate
+	group.GET("/:profile", gin.WrapF(pprof.Index))
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"net/http"
+
+	"postal-api/internal/grpcapi/postalpb"
+	"postal-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+// protobufContentType is the media type of protobuf encoded responses
+const protobufContentType = "application/x-protobuf"
+
+// wantsProtobuf reports whether the client prefers a protobuf encoded response over JSON
+func wantsProtobuf(c *gin.Context) bool {
+	return prefersOverJSON(c, protobufContentType)
+}
+
+// respondSearchProtobuf writes a search response as the postal.v1.SearchResponse message of the gRPC API
+func respondSearchProtobuf(c *gin.Context, response *services.SearchResponse) {
+	body, err := proto.Marshal(postalpb.FromSearchResponse(response))
+	if err != nil {
+		respondInternalError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, protobufContentType, body)
+}
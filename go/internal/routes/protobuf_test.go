@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"postal-api/internal/database"
+	"postal-api/internal/grpcapi/postalpb"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSearchEncodesProtobufWhenAccepted(t *testing.T) {
+	if _, err := os.Stat(testDBPath); err != nil {
+		t.Skip("Database file postal_codes.db not found")
+	}
+	if err := database.InitializeWithPath(testDBPath); err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	get := func(path, accept string) (*http.Response, []byte) {
+		t.Helper()
+		request, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if accept != "" {
+			request.Header.Set("Accept", accept)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return response, body
+	}
+
+	path := "/postal-codes?city=Kraków&street=Floriańska&limit=5"
+	response, protoBody := get(path, protobufContentType)
+	if response.StatusCode != http.StatusOK || response.Header.Get("Content-Type") != protobufContentType {
+		t.Fatalf("expected a protobuf response, got %d %s", response.StatusCode, response.Header.Get("Content-Type"))
+	}
+	var message postalpb.SearchResponse
+	if err := proto.Unmarshal(protoBody, &message); err != nil {
+		t.Fatalf("failed to decode protobuf body: %v", err)
+	}
+
+	_, body := get(path, "")
+	var plain struct {
+		Count      int    `json:"count"`
+		SearchType string `json:"search_type"`
+		Results    []struct {
+			PostalCode string `json:"postal_code"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &plain); err != nil {
+		t.Fatalf("expected JSON by default: %v", err)
+	}
+	if int(message.GetCount()) != plain.Count || message.GetSearchType() != plain.SearchType || len(message.GetResults()) != len(plain.Results) {
+		t.Fatalf("protobuf response %v does not match JSON response %+v", &message, plain)
+	}
+	for i, result := range message.GetResults() {
+		if result.GetPostalCode() != plain.Results[i].PostalCode {
+			t.Errorf("result %d: expected %s, got %s", i, plain.Results[i].PostalCode, result.GetPostalCode())
+		}
+	}
+	if len(protoBody) >= len(body) {
+		t.Errorf("expected the protobuf body to be smaller than the JSON body")
+	}
+
+	if vary := response.Header.Values("Vary"); !slices.Contains(vary, "Accept") {
+		t.Errorf("expected Vary to include Accept, got %v", vary)
+	}
+
+	response, _ = get(path, "application/json, application/x-protobuf;q=0")
+	if response.Header.Get("Content-Type") == protobufContentType {
+		t.Errorf("expected q=0 to rule out protobuf")
+	}
+
+	for _, param := range []string{"dedupe=true", "include_meta=true", "debug=true"} {
+		response, _ = get(path+"&"+param, protobufContentType)
+		if response.StatusCode != http.StatusUnprocessableEntity {
+			t.Errorf("expected %s with protobuf to be rejected, got %d", param, response.StatusCode)
+		}
+	}
+}
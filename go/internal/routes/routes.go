@@ -1,11 +1,25 @@
 package routes
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"postal-api/internal/auth"
+	"postal-api/internal/config"
+	"postal-api/internal/database"
+	"postal-api/internal/i18n"
+	"postal-api/internal/request"
 	"postal-api/internal/services"
 	"postal-api/internal/utils"
 
@@ -17,21 +31,286 @@ func trimParam(value string) string {
 	return strings.TrimSpace(value)
 }
 
-// stringPtr returns a pointer to the string if it's not empty, otherwise nil
-func stringPtr(s string) *string {
-	if s == "" {
-		return nil
+// respondWithETag writes a JSON response with an ETag and Cache-Control header,
+// replying 304 Not Modified when the client already holds the same representation
+func respondWithETag(c *gin.Context, body interface{}) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		respondInternalError(c, err)
+		return
+	}
+
+	hash := sha256.Sum256(payload)
+	etag := fmt.Sprintf("\"%x\"", hash[:16])
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(config.LocationCacheTTL().Seconds())))
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", payload)
+}
+
+// etagMatches checks an If-None-Match header value against an ETag, allowing lists, weak tags and "*"
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// queryTimeoutMiddleware bounds each request's context by the configured query timeout,
+// or by the longer export timeout for an unlimited NDJSON export
+func queryTimeoutMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := config.QueryTimeout()
+		if isUnlimitedExport(c) {
+			timeout = config.ExportTimeout()
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// requestIDHeader carries the request ID in both directions
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client supplied request IDs before they are echoed and logged
+const maxRequestIDLength = 64
+
+// requestIDMiddleware assigns each request an ID, reusing a reasonable client supplied one,
+// so error responses can be matched with server logs
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := strings.TrimSpace(c.GetHeader(requestIDHeader))
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+
+		c.Set("request_id", requestID)
+		c.Header(requestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// newRequestID returns a random 16 character hex ID
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// respondInternalError logs the full error with the request ID and writes a 500.
+// The raw error is only exposed in debug mode (GIN_MODE=debug); release mode, the default, returns a generic message.
+func respondInternalError(c *gin.Context, err error) {
+	requestID := c.GetString("request_id")
+	log.Printf("[%s] %s %s failed: %v", requestID, c.Request.Method, c.Request.URL.Path, err)
+
+	message := "Internal server error"
+	if gin.Mode() == gin.DebugMode {
+		message = fmt.Sprintf("Internal server error: %v", err)
+	}
+	respondErrorWithDetails(c, http.StatusInternalServerError, CodeInternal, message, gin.H{"request_id": requestID})
+}
+
+// debugEnabled reports whether debug output such as generated SQL may be returned; it is never exposed in release mode
+func debugEnabled() bool {
+	return gin.Mode() != gin.ReleaseMode
+}
+
+// languageMiddleware stores the response language negotiated from Accept-Language in the request context
+func languageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(i18n.WithLanguage(c.Request.Context(), lang))
+		c.Header("Content-Language", lang)
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// statusClientClosedRequest is the non-standard status logged when the client disconnects mid-request
+const statusClientClosedRequest = 499
+
+// respondServiceError writes 503 for timed out queries, 499 for cancelled requests and 500 for any other service error
+func respondServiceError(c *gin.Context, err error) {
+	if errors.Is(err, context.Canceled) {
+		// The client is gone, so there is nobody to send a body to
+		c.AbortWithStatus(statusClientClosedRequest)
+		return
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		respondError(c, http.StatusServiceUnavailable, CodeTimeout, "Database query timed out")
+		return
+	}
+	respondInternalError(c, err)
+}
+
+// parseLocationSort validates the sort parameter of location list endpoints against "locale" and any extra keys
+func parseLocationSort(v *paramValidator, extra ...string) string {
+	sort := trimParam(v.c.Query("sort"))
+	if sort == "" || sort == "locale" || slices.Contains(extra, sort) {
+		return sort
+	}
+
+	allowed := append([]string{"locale"}, extra...)
+	v.fail("sort", i18n.MsgOneOf, strings.Join(allowed, ", "))
+	return ""
+}
+
+// parseMatchMode reports whether match=contains asked for substring instead of prefix matching of the prefix filter
+func parseMatchMode(v *paramValidator) bool {
+	switch trimParam(v.c.Query("match")) {
+	case "", "prefix":
+		return false
+	case "contains":
+		return true
+	}
+	v.fail("match", i18n.MsgOneOf, "prefix, contains")
+	return false
+}
+
+// parseCountListOptions reads the limit, offset, sort=count|alpha and order parameters of city lists ranked by a
+// count. Counts list largest first; alphabetical order defaults to A-Z.
+func parseCountListOptions(v *paramValidator) services.ListOptions {
+	limit := min(v.positiveInt("limit", config.DefaultLocationListLimit), config.MaxLocationListLimit())
+	offset := v.nonNegativeInt("offset", 0)
+	opts := services.ListOptions{Limit: limit, Offset: offset, Sort: trimParam(v.c.Query("sort"))}
+
+	switch opts.Sort {
+	case "":
+		opts.Sort = "count"
+	case "count", "alpha":
+	default:
+		v.fail("sort", i18n.MsgOneOf, "count, alpha")
+	}
+	switch trimParam(v.c.Query("order")) {
+	case "":
+		opts.Descending = opts.Sort == "count"
+	case "asc":
+		opts.Descending = false
+	case "desc":
+		opts.Descending = true
+	default:
+		v.fail("order", i18n.MsgOneOf, "asc, desc")
+	}
+	return opts
+}
+
+// parseListOptions reads the sort, limit and offset parameters of paginated location lists.
+// Limits above the configured maximum are clamped rather than rejected.
+func parseListOptions(v *paramValidator, extraSorts ...string) services.ListOptions {
+	sort := parseLocationSort(v, extraSorts...)
+	limit := min(v.positiveInt("limit", config.DefaultLocationListLimit), config.MaxLocationListLimit())
+	offset := v.nonNegativeInt("offset", 0)
+
+	return services.ListOptions{Limit: limit, Offset: offset, LocaleSort: sort == "locale", Sort: sort}
+}
+
+// parseListFilter normalizes a comma-separated filter like "mazowieckie, łódzkie" and enforces the size limit
+func parseListFilter(v *paramValidator, name string) string {
+	value, ok := request.ListFilter(v.c.Query(name))
+	if !ok {
+		v.fail(name, i18n.MsgMaxListValues, request.MaxListFilterValues)
+	}
+	return value
+}
+
+// parsePostalCodeFilter reads an optional postal_code filter, accepting dashless codes such as 00001
+func parsePostalCodeFilter(v *paramValidator) string {
+	value := trimParam(v.c.Query("postal_code"))
+	if value == "" {
+		return ""
 	}
-	return &s
+
+	postalCode, ok := utils.NormalizePostalCode(value)
+	if !ok {
+		v.fail("postal_code", i18n.MsgPostalCodeFormat)
+		return ""
+	}
+	return postalCode
+}
+
+// BuildInfo describes the running build, injected into main via -ldflags
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
+// buildInfo is reported by the version endpoint
+var buildInfo = BuildInfo{Version: "dev", Commit: "unknown", BuildDate: "unknown"}
+
+// SetBuildInfo records the build metadata reported by the version endpoint
+func SetBuildInfo(info BuildInfo) {
+	buildInfo = info
+}
+
+// startTime is when the process started, used to report uptime
+var startTime = time.Now()
+
+// SetStartTime records when the process started
+func SetStartTime(t time.Time) {
+	startTime = t
 }
 
 // RegisterRoutes registers all routes with the Gin router
 func RegisterRoutes(router *gin.Engine) {
+	// Report the handling time of every response, including rejected requests
+	router.Use(responseTimeMiddleware())
+
+	// Tag every request so errors can be traced in the logs
+	router.Use(requestIDMiddleware())
+
+	// Localize messages according to Accept-Language, including authentication errors
+	router.Use(languageMiddleware())
+
+	// Require an API key when keys are configured; the admin key is accepted everywhere
+	adminKey := config.AdminAPIKey()
+	router.Use(apiKeyMiddleware(auth.WithAdminKey(apiKeys, adminKey)))
+
+	// Cancel database work that exceeds the configured timeout
+	router.Use(queryTimeoutMiddleware())
+
 	// Postal codes search endpoint
 	router.GET("/postal-codes", searchPostalCodesHandler)
 
+	// Yes/no check that an address exists
+	router.GET("/validate-address", validateAddressHandler)
+
+	// Ranked full-text search over city and street names
+	router.GET("/search/fts", fullTextSearchHandler)
+
+	// Labeled province, county, city and street suggestions for a search bar
+	router.GET("/search/suggest", suggestHandler)
+
+	// Nearest postal codes by coordinates
+	router.GET("/postal-codes/nearest", nearestPostalCodesHandler)
+	router.GET("/postal-codes/within", boundingBoxHandler)
+
+	// Total number of records, for monitoring dataset freshness
+	router.GET("/postal-codes/count", countPostalCodesHandler)
+
+	// Random records for fixtures and smoke tests
+	router.GET("/postal-codes/sample", samplePostalCodesHandler)
+
 	// Direct postal code lookup
 	router.GET("/postal-codes/:postal_code", getPostalCodeHandler)
+	// HEAD is a cheap existence check: the same status codes, and net/http drops the body
+	router.HEAD("/postal-codes/:postal_code", getPostalCodeHandler)
+
+	// Postal district summary by the first two digits of the postal code
+	router.GET("/regions/:prefix", getRegionHandler)
 
 	// Location endpoints directory
 	router.GET("/locations", getLocationsHandler)
@@ -41,80 +320,437 @@ func RegisterRoutes(router *gin.Engine) {
 	router.GET("/locations/counties", getCountiesHandler)
 	router.GET("/locations/municipalities", getMunicipalitiesHandler)
 	router.GET("/locations/cities", getCitiesHandler)
+	router.GET("/locations/cities/multi-code", getMultiCodeCitiesHandler)
 	router.GET("/locations/streets", getStreetsHandler)
+	router.GET("/locations/streets/detailed", getDetailedStreetsHandler)
+	router.GET("/locations/streets/house-numbers", getStreetHouseNumberingHandler)
+	router.GET("/locations/tree", getLocationTreeHandler)
+	router.GET("/locations/distinct/:column", getDistinctValuesHandler)
+
+	// House number utilities
+	router.GET("/house-number/match", houseNumberMatchHandler)
+	router.GET("/house-number/parse", houseNumberParseHandler)
+
+	// Text normalization preview
+	router.GET("/utils/normalize", normalizeTextHandler)
+
+	// Dataset statistics
+	router.GET("/stats", getStatsHandler)
+	router.GET("/stats/streets-per-city", getStreetsPerCityHandler)
 
 	// Health check endpoint
 	router.GET("/health", healthCheckHandler)
+	router.GET("/health/details", healthDetailsHandler)
+	router.GET("/health/ready", readinessHandler)
+
+	// Build metadata
+	router.GET("/version", versionHandler)
+
+	// Dataset version and freshness
+	router.GET("/meta", metaHandler)
+
+	// Administration endpoints, only when an admin key is configured
+	if adminKey != "" {
+		admin := router.Group("/admin", adminKeyMiddleware(adminKey))
+		admin.GET("/usage", getUsageHandler)
+		admin.DELETE("/usage", resetUsageHandler)
+		admin.GET("/duplicates", findDuplicatesHandler)
+		admin.POST("/reload", reloadDatabaseHandler)
+	}
+
+	// Runtime internals for ad-hoc inspection, only when explicitly enabled
+	if config.DebugEndpoints() {
+		router.GET("/debug/db-stats", dbStatsHandler)
+	}
+
+	// CPU and memory profiling, off by default
+	if config.PprofEnabled() {
+		registerPprofRoutes(router)
+	}
+
+	// JSON errors for unknown paths and unsupported methods
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(notFoundHandler)
+	router.NoMethod(methodNotAllowedHandler)
 }
 
 // searchPostalCodesHandler handles the postal codes search endpoint
 func searchPostalCodesHandler(c *gin.Context) {
-	// Get query parameters and trim whitespace
-	city := trimParam(c.Query("city"))
-	street := trimParam(c.Query("street"))
-	houseNumber := trimParam(c.Query("house_number"))
-	province := trimParam(c.Query("province"))
-	county := trimParam(c.Query("county"))
-	municipality := trimParam(c.Query("municipality"))
-	limitStr := c.DefaultQuery("limit", "100")
+	// The encoding depends on Accept, so caches must keep the variants apart
+	c.Writer.Header().Add("Vary", "Accept")
+	v := newParamValidator(c)
+	// normalize=false skips the Polish normalization tiers; without the parameter the server default applies
+	skipNormalization := !v.boolean("normalize", !config.PolishNormalizationDisabled())
+	// fallback=false returns exact (and normalized) matches only, never a broader location
+	skipFallback := !v.boolean("fallback", true)
+	dedupe := trimParam(c.Query("dedupe")) == "true"
+	groupBy := trimParam(c.Query("group_by"))
 
-	// City parameter is mandatory
-	if city == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "City parameter is required"})
-		return
+	// Parse the optional field selection
+	var fields []string
+	if fieldsStr := trimParam(c.Query("fields")); fieldsStr != "" {
+		for _, field := range strings.Split(fieldsStr, ",") {
+			field = strings.TrimSpace(field)
+			if !database.IsPostalCodeField(field) {
+				v.fail("fields", i18n.MsgUnknownField, field, strings.Join(database.PostalCodeFields, ", "))
+				continue
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	// Parse limit; limit=0 exports every match as NDJSON when allowed
+	limit := 0
+	if trimParam(c.Query("limit")) == "0" {
+		if !wantsNDJSON(c) || !config.UnlimitedExport() {
+			v.fail("limit", i18n.MsgUnlimitedExport)
+		}
+	} else {
+		limit = v.positiveInt("limit", config.SearchLimit())
+	}
+
+	// Protobuf responses carry the flat result list only
+	if wantsProtobuf(c) {
+		if len(fields) > 0 {
+			v.fail("fields", i18n.MsgNotWithProtobuf)
+		}
+		if dedupe {
+			v.fail("dedupe", i18n.MsgNotWithProtobuf)
+		}
+		if groupBy != "" {
+			v.fail("group_by", i18n.MsgNotWithProtobuf)
+		}
+		if trimParam(c.Query("include_meta")) == "true" {
+			v.fail("include_meta", i18n.MsgNotWithProtobuf)
+		}
+		if trimParam(c.Query("debug")) != "" {
+			v.fail("debug", i18n.MsgNotWithProtobuf)
+		}
 	}
 
-	// Parse limit
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 {
-		limit = 100
+	// Grouped results have their own shape, so fields cannot select from them, and NDJSON streams flat rows
+	if groupBy != "" && len(fields) > 0 {
+		v.fail("group_by", i18n.MsgNotCombinable, "fields")
+	}
+	if groupBy != "" && wantsNDJSON(c) {
+		v.fail("group_by", i18n.MsgNotCombinable, "NDJSON")
+	}
+	// Merged rows need every row of their group, which a line-by-line stream does not hold
+	if dedupe && wantsNDJSON(c) {
+		v.fail("dedupe", i18n.MsgNotCombinable, "NDJSON")
 	}
 
-	// Create search parameters
-	params := utils.SearchParams{
-		City:         stringPtr(city),
-		Street:       stringPtr(street),
-		HouseNumber:  stringPtr(houseNumber),
-		Province:     stringPtr(province),
-		County:       stringPtr(county),
-		Municipality: stringPtr(municipality),
+	// Validate the search itself with the rules shared with the gRPC API
+	params, limitClamped, fieldErrors := request.Search{
+		City:         c.Query("city"),
+		Street:       c.Query("street"),
+		HouseNumber:  c.Query("house_number"),
+		Municipality: c.Query("municipality"),
+		Province:     c.Query("province"),
+		County:       c.Query("county"),
+		Sort:         c.Query("sort"),
+		Side:         c.Query("side"),
+		GroupBy:      groupBy,
 		Limit:        limit,
+
+		Exact:              trimParam(c.Query("exact")) == "true",
+		Fuzzy:              trimParam(c.Query("fuzzy")) == "true",
+		Phonetic:           trimParam(c.Query("phonetic")) == "true",
+		Loose:              trimParam(c.Query("loose")) == "true",
+		CaseSensitive:      trimParam(c.Query("case_sensitive")) == "true",
+		AssumeAllWhenEmpty: trimParam(c.Query("assume_all_when_empty")) == "true",
+		SkipNormalization:  skipNormalization,
+		SkipFallback:       skipFallback,
+		Dedupe:             dedupe,
+	}.Params()
+	v.failFields(fieldErrors)
+	if v.respondIfInvalid() {
+		return
+	}
+
+	// Count-only mode skips materializing the results
+	if trimParam(c.Query("count_only")) == "true" {
+		countResponse, err := services.CountPostalCodes(c.Request.Context(), params)
+		if err != nil {
+			respondServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, countResponse)
+		return
+	}
+
+	// NDJSON writes results line by line as they are read instead of as one array
+	if wantsNDJSON(c) {
+		streamSearchResults(c, params, fields)
+		return
+	}
+
+	// Collect the generated SQL (and optionally the query plans) when debugging outside release mode
+	ctx := c.Request.Context()
+	var debugInfo *services.DebugInfo
+	if debugEnabled() {
+		switch trimParam(c.Query("debug")) {
+		case "true":
+			ctx, debugInfo = services.WithDebug(ctx, false)
+		case "plan":
+			ctx, debugInfo = services.WithDebug(ctx, true)
+		}
 	}
 
 	// Execute search
-	response, err := services.SearchPostalCodes(params)
+	response, err := services.SearchPostalCodes(ctx, params)
 	if err != nil {
-		// Log the actual error for debugging
-		fmt.Printf("Search error: %v\n", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Internal server error: %v", err)})
+		respondServiceError(c, err)
 		return
 	}
+	response.LimitClamped = limitClamped
+	response.SetFields(fields)
+	response.Debug = debugInfo
+	if trimParam(c.Query("include_meta")) == "true" {
+		meta, err := services.GetMeta(ctx)
+		if err != nil {
+			respondServiceError(c, err)
+			return
+		}
+		response.Meta = meta
+	}
 
+	// Protobuf is opt-in through the Accept header; JSON stays the default
+	if wantsProtobuf(c) {
+		respondSearchProtobuf(c, response)
+		return
+	}
 	c.JSON(http.StatusOK, response)
 }
 
+// validateAddressHandler reports whether a city, street and house number exist and under which postal code
+func validateAddressHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	city := utils.NormalizeWhitespace(v.required("city"))
+	street := utils.NormalizeWhitespace(c.Query("street"))
+	houseNumber := trimParam(c.Query("house_number"))
+	if houseNumber != "" && street == "" {
+		v.fail("street", i18n.MsgRequired)
+	}
+	if v.respondIfInvalid() {
+		return
+	}
+
+	validation, err := services.ValidateAddress(c.Request.Context(), city, street, houseNumber)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, validation)
+}
+
 // getPostalCodeHandler handles direct postal code lookup
 func getPostalCodeHandler(c *gin.Context) {
-	postalCode := c.Param("postal_code")
-	if postalCode == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Postal code parameter is required"})
+	// Accept dashless codes such as 00001 and look them up as 00-001
+	postalCode, ok := utils.NormalizePostalCode(c.Param("postal_code"))
+	v := newParamValidator(c)
+	if !ok {
+		v.fail("postal_code", i18n.MsgPostalCodeFormat)
+	}
+	if expand := trimParam(c.Query("expand")); expand != "" && expand != "hierarchy" {
+		v.fail("expand", i18n.MsgOneOf, "hierarchy")
+	}
+	if v.respondIfInvalid() {
 		return
 	}
 
-	result, err := services.GetPostalCodeByCode(postalCode)
+	result, err := services.GetPostalCodeByCode(c.Request.Context(), postalCode)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondServiceError(c, err)
 		return
 	}
 
 	if result == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Postal code not found"})
+		respondError(c, http.StatusNotFound, CodeNotFound, "Postal code not found")
+		return
+	}
+
+	if trimParam(c.Query("expand")) == "hierarchy" {
+		c.JSON(http.StatusOK, services.HierarchyResponse{
+			PostalCode: postalCode,
+			Count:      result.Count,
+			Hierarchy:  services.BuildHierarchy(result.Results),
+		})
 		return
 	}
 
 	c.JSON(http.StatusOK, result)
 }
 
+// suggestHandler suggests provinces, counties, cities and streets starting with the typed text
+func suggestHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	query := utils.NormalizeWhitespace(v.required("q"))
+	limit := min(v.positiveInt("limit", config.DefaultSuggestLimit), config.MaxSuggestLimit)
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.Suggest(c.Request.Context(), query, limit)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// fullTextSearchHandler handles ranked full-text search over city and street names
+func fullTextSearchHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	query := utils.NormalizeWhitespace(v.required("q"))
+	limit := min(v.positiveInt("limit", 20), config.MaxSearchLimit())
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.SearchFullText(c.Request.Context(), query, limit)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// countPostalCodesHandler returns the cached total number of postal code records
+func countPostalCodesHandler(c *gin.Context) {
+	count, err := services.GetRecordCount(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, services.CountResponse{Count: count})
+}
+
+// samplePostalCodesHandler returns random postal code records; n is capped at services.MaxSampleSize
+func samplePostalCodesHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	n := min(v.positiveInt("n", 5), services.MaxSampleSize)
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.SamplePostalCodes(c.Request.Context(), n)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// nearestPostalCodesHandler handles nearest postal code lookup by coordinates
+func nearestPostalCodesHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	lat := v.float("lat")
+	if !utils.IsValidLatitude(lat) {
+		v.fail("lat", i18n.MsgLatitudeRange)
+	}
+	lon := v.float("lon")
+	if !utils.IsValidLongitude(lon) {
+		v.fail("lon", i18n.MsgLongitudeRange)
+	}
+	limit := min(v.positiveInt("limit", 10), config.MaxSearchLimit())
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.FindNearestPostalCodes(c.Request.Context(), lat, lon, limit)
+	if errors.Is(err, services.ErrCoordinatesUnavailable) {
+		respondError(c, http.StatusNotImplemented, CodeNotImplemented, "Coordinates are not available in this database")
+		return
+	}
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// boundingBoxHandler handles postal code search within a latitude/longitude rectangle
+func boundingBoxHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	minLat, maxLat := v.float("min_lat"), v.float("max_lat")
+	minLon, maxLon := v.float("min_lon"), v.float("max_lon")
+
+	if !utils.IsValidLatitude(minLat) {
+		v.fail("min_lat", i18n.MsgLatitudeRange)
+	}
+	if !utils.IsValidLatitude(maxLat) {
+		v.fail("max_lat", i18n.MsgLatitudeRange)
+	}
+	if !utils.IsValidLongitude(minLon) {
+		v.fail("min_lon", i18n.MsgLongitudeRange)
+	}
+	if !utils.IsValidLongitude(maxLon) {
+		v.fail("max_lon", i18n.MsgLongitudeRange)
+	}
+	// Ordering is only meaningful once every bound is valid
+	boundsValid := len(v.errors) == 0
+	if boundsValid && minLat >= maxLat {
+		v.fail("min_lat", i18n.MsgLessThan, "max_lat")
+	}
+	if boundsValid && minLon >= maxLon {
+		v.fail("min_lon", i18n.MsgLessThan, "max_lon")
+	}
+
+	limit := v.positiveInt("limit", 100)
+	if v.respondIfInvalid() {
+		return
+	}
+	limitClamped := false
+	if maxLimit := config.MaxSearchLimit(); limit > maxLimit {
+		limit = maxLimit
+		limitClamped = true
+	}
+
+	response, err := services.SearchWithinBoundingBox(c.Request.Context(), minLat, maxLat, minLon, maxLon, limit)
+	if errors.Is(err, services.ErrCoordinatesUnavailable) {
+		respondError(c, http.StatusNotImplemented, CodeNotImplemented, "Coordinates are not available in this database")
+		return
+	}
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	response.LimitClamped = limitClamped
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getRegionHandler summarizes the provinces and cities of a two-digit postal district
+func getRegionHandler(c *gin.Context) {
+	prefix := c.Param("prefix")
+	v := newParamValidator(c)
+	if !utils.IsPostalCodePrefix(prefix) {
+		v.fail("prefix", i18n.MsgPostalPrefixFormat)
+	}
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetRegion(c.Request.Context(), prefix)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	if response == nil {
+		respondError(c, http.StatusNotFound, CodeNotFound, i18n.TranslateContext(c.Request.Context(), i18n.MsgNoPostalCodesWithPrefix))
+		return
+	}
+
+	respondWithETag(c, response)
+}
+
 // getLocationsHandler returns available location endpoints
 func getLocationsHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -123,87 +759,449 @@ func getLocationsHandler(c *gin.Context) {
 			"counties":       "/locations/counties",
 			"municipalities": "/locations/municipalities",
 			"cities":         "/locations/cities",
+			"multi_code":     "/locations/cities/multi-code",
 			"streets":        "/locations/streets",
+			"street_codes":   "/locations/streets/detailed",
+			"house_numbers":  "/locations/streets/house-numbers",
+			"tree":           "/locations/tree",
+			"distinct":       "/locations/distinct/{column}",
 		},
 	})
 }
 
+// getDistinctValuesHandler lists the distinct values of one whitelisted column
+func getDistinctValuesHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	opts := parseListOptions(v)
+
+	column := c.Param("column")
+	if !slices.Contains(services.DistinctColumns(), column) {
+		v.fail("column", i18n.MsgOneOf, strings.Join(services.DistinctColumns(), ", "))
+	}
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetDistinctValues(c.Request.Context(), column, opts)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondWithETag(c, response)
+}
+
 // getProvincesHandler handles provinces endpoint
 func getProvincesHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	sort := parseLocationSort(v)
+	contains := parseMatchMode(v)
+	if v.respondIfInvalid() {
+		return
+	}
+
 	prefix := trimParam(c.Query("prefix"))
 
-	response, err := services.GetProvinces(stringPtr(prefix))
+	response, err := services.GetProvinces(c.Request.Context(), request.Optional(prefix), contains)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	// Sort a copy so the cached response keeps its database order
+	if sort == "locale" {
+		sorted := *response
+		sorted.Provinces = utils.SortedPolish(response.Provinces)
+		response = &sorted
+	}
+
+	respondWithETag(c, response)
 }
 
 // getCountiesHandler handles counties endpoint
 func getCountiesHandler(c *gin.Context) {
-	province := trimParam(c.Query("province"))
+	v := newParamValidator(c)
+	opts := parseListOptions(v)
+	opts.Contains = parseMatchMode(v)
+
+	province := parseListFilter(v, "province")
 	prefix := trimParam(c.Query("prefix"))
 
-	response, err := services.GetCounties(stringPtr(province), stringPtr(prefix))
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetCounties(c.Request.Context(), request.Optional(province), request.Optional(prefix), opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	respondWithETag(c, response)
 }
 
 // getMunicipalitiesHandler handles municipalities endpoint
 func getMunicipalitiesHandler(c *gin.Context) {
-	province := trimParam(c.Query("province"))
-	county := trimParam(c.Query("county"))
+	v := newParamValidator(c)
+	opts := parseListOptions(v)
+	opts.Contains = parseMatchMode(v)
+
+	province := parseListFilter(v, "province")
+	county := parseListFilter(v, "county")
 	prefix := trimParam(c.Query("prefix"))
 
-	response, err := services.GetMunicipalities(stringPtr(province), stringPtr(county), stringPtr(prefix))
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetMunicipalities(c.Request.Context(), request.Optional(province), request.Optional(county), request.Optional(prefix), opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	respondWithETag(c, response)
 }
 
 // getCitiesHandler handles cities endpoint
 func getCitiesHandler(c *gin.Context) {
-	province := trimParam(c.Query("province"))
-	county := trimParam(c.Query("county"))
+	v := newParamValidator(c)
+	opts := parseListOptions(v, "population", "alpha")
+	opts.Contains = parseMatchMode(v)
+
+	// Population is the default key and lists largest first; alphabetical orders default to A-Z
+	if opts.Sort == "" {
+		opts.Sort = "population"
+	}
+	switch trimParam(c.Query("order")) {
+	case "":
+		opts.Descending = opts.Sort == "population"
+	case "asc":
+		opts.Descending = false
+	case "desc":
+		opts.Descending = true
+	default:
+		v.fail("order", i18n.MsgOneOf, "asc, desc")
+	}
+	opts.IncludePopulation = trimParam(c.Query("include_population")) == "true"
+
+	province := parseListFilter(v, "province")
+	county := parseListFilter(v, "county")
 	municipality := trimParam(c.Query("municipality"))
 	prefix := trimParam(c.Query("prefix"))
 
-	response, err := services.GetCities(stringPtr(province), stringPtr(county), stringPtr(municipality), stringPtr(prefix))
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetCities(c.Request.Context(), request.Optional(province), request.Optional(county), request.Optional(municipality), request.Optional(prefix), opts)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	respondWithETag(c, response)
+}
+
+// getMultiCodeCitiesHandler lists cities with more than one postal code, most codes first by default
+func getMultiCodeCitiesHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	opts := parseCountListOptions(v)
+
+	province := parseListFilter(v, "province")
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetMultiCodeCities(c.Request.Context(), request.Optional(province), opts)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondWithETag(c, response)
 }
 
 // getStreetsHandler handles streets endpoint
 func getStreetsHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	opts := parseListOptions(v)
+	opts.Contains = parseMatchMode(v)
+	opts.IncludePostalCodes = trimParam(c.Query("include_postal_codes")) == "true"
+
 	city := trimParam(c.Query("city"))
-	province := trimParam(c.Query("province"))
-	county := trimParam(c.Query("county"))
+	province := parseListFilter(v, "province")
+	county := parseListFilter(v, "county")
 	municipality := trimParam(c.Query("municipality"))
-	prefix := trimParam(c.Query("prefix"))
+	prefix := utils.StripStreetPrefix(c.Query("prefix"))
+	postalCode := parsePostalCodeFilter(v)
+
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetStreets(c.Request.Context(), request.Optional(city), request.Optional(province), request.Optional(county), request.Optional(municipality), request.Optional(prefix), request.Optional(postalCode), opts)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondWithETag(c, response)
+}
+
+// getDetailedStreetsHandler handles the streets of a city paired with their postal codes
+func getDetailedStreetsHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	opts := parseListOptions(v)
+	opts.Contains = parseMatchMode(v)
+
+	city := v.required("city")
+	province := parseListFilter(v, "province")
+	county := parseListFilter(v, "county")
+	municipality := trimParam(c.Query("municipality"))
+	prefix := utils.StripStreetPrefix(c.Query("prefix"))
+	postalCode := parsePostalCodeFilter(v)
+
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetDetailedStreets(c.Request.Context(), request.Optional(city), request.Optional(province), request.Optional(county), request.Optional(municipality), request.Optional(prefix), request.Optional(postalCode), opts)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondWithETag(c, response)
+}
+
+// getStreetHouseNumberingHandler reports whether a street of a city lists house number ranges
+func getStreetHouseNumberingHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	city := utils.NormalizeWhitespace(v.required("city"))
+	street := utils.NormalizeWhitespace(v.required("street"))
+	if v.respondIfInvalid() {
+		return
+	}
 
-	response, err := services.GetStreets(stringPtr(city), stringPtr(province), stringPtr(county), stringPtr(municipality), stringPtr(prefix))
+	response, err := services.GetStreetHouseNumbering(c.Request.Context(), city, street)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		respondServiceError(c, err)
+		return
+	}
+
+	if response == nil {
+		respondError(c, http.StatusNotFound, CodeNotFound, i18n.TranslateContext(c.Request.Context(), i18n.MsgStreetNotInCity))
+		return
+	}
+
+	respondWithETag(c, response)
+}
+
+// getLocationTreeHandler handles the nested location tree endpoint
+func getLocationTreeHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	province := utils.NormalizeWhitespace(v.required("province"))
+	depth := v.positiveInt("depth", services.TreeDepthCities)
+	if depth > services.TreeDepthCities {
+		v.fail("depth", i18n.MsgTreeDepth)
+	}
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetLocationTree(c.Request.Context(), province, depth)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondWithETag(c, response)
+}
+
+// houseNumberMatchHandler checks whether a house number falls within a range string
+func houseNumberMatchHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	number := v.required("number")
+	rangeString := v.required("range")
+	if v.respondIfInvalid() {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"number":         number,
+		"range":          rangeString,
+		"matches":        utils.IsHouseNumberInRange(number, rangeString),
+		"interpretation": utils.ParseHouseNumberRange(rangeString),
+	})
+}
+
+// houseNumberParseHandler explains how a house number range string is interpreted
+func houseNumberParseHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	rangeString := v.required("range")
+	if v.respondIfInvalid() {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"range":          rangeString,
+		"interpretation": utils.ParseHouseNumberRange(rangeString),
+	})
+}
+
+// normalizeTextHandler shows how text is normalized for Polish character search
+func normalizeTextHandler(c *gin.Context) {
+	text := c.Query("text")
+
+	c.JSON(http.StatusOK, gin.H{
+		"original":              text,
+		"normalized":            utils.NormalizePolishText(text),
+		"has_polish_characters": utils.HasPolishCharacters(text),
+	})
+}
+
+// getStatsHandler handles the stats endpoint
+func getStatsHandler(c *gin.Context) {
+	response, err := services.GetStats(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// getStreetsPerCityHandler lists the number of distinct streets of each city
+func getStreetsPerCityHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	opts := parseCountListOptions(v)
+
+	province := parseListFilter(v, "province")
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.GetStreetsPerCity(c.Request.Context(), request.Optional(province), opts)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	respondWithETag(c, response)
+}
+
+// metaHandler reports the dataset version and generation time with the time the response was served
+func metaHandler(c *gin.Context) {
+	meta, err := services.GetMeta(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		*services.MetaResponse
+		ServedAt string `json:"served_at"`
+	}{meta, time.Now().UTC().Format(time.RFC3339)})
+}
+
 // healthCheckHandler handles health check endpoint
 func healthCheckHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+}
+
+// healthDetailsHandler reports uptime and the number of loaded records alongside the health status
+func healthDetailsHandler(c *gin.Context) {
+	recordCount, err := services.GetRecordCount(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	uptime := time.Since(startTime)
+	c.JSON(http.StatusOK, gin.H{
+		"status":         "healthy",
+		"started_at":     startTime.UTC().Format(time.RFC3339),
+		"uptime_seconds": int64(uptime.Seconds()),
+		"uptime":         uptime.Round(time.Second).String(),
+		"record_count":   recordCount,
+	})
+}
+
+// readinessHandler reports whether the database connection is usable, answering 503 while it is being reopened
+func readinessHandler(c *gin.Context) {
+	health := database.Health()
+	if !health.Healthy {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "database": health})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ready", "database": health})
+}
+
+// versionHandler reports the version, commit and build date of the running binary
+func versionHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, buildInfo)
+}
+
+// maxDuplicateGroups caps the page size of /admin/duplicates
+const maxDuplicateGroups = 1000
+
+// findDuplicatesHandler reports groups of identical postal code rows, paginated
+func findDuplicatesHandler(c *gin.Context) {
+	v := newParamValidator(c)
+	limit := min(v.positiveInt("limit", 100), maxDuplicateGroups)
+	offset := v.nonNegativeInt("offset", 0)
+	if v.respondIfInvalid() {
+		return
+	}
+
+	response, err := services.FindDuplicates(c.Request.Context(), limit, offset)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// reloadDatabaseHandler reopens the database file and reports the number of records it now holds
+func reloadDatabaseHandler(c *gin.Context) {
+	if err := services.ReloadDatabase(); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	recordCount, err := services.GetRecordCount(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "reloaded", "record_count": recordCount})
+}
+
+// dbStatsHandler reports the connection pool statistics of the database
+func dbStatsHandler(c *gin.Context) {
+	stats := database.GetDB().Stats()
+	c.JSON(http.StatusOK, gin.H{
+		"driver":               database.Driver(),
+		"max_open_connections": stats.MaxOpenConnections,
+		"open_connections":     stats.OpenConnections,
+		"in_use":               stats.InUse,
+		"idle":                 stats.Idle,
+		"wait_count":           stats.WaitCount,
+		"wait_duration_ms":     stats.WaitDuration.Milliseconds(),
+		"max_idle_closed":      stats.MaxIdleClosed,
+		"max_idle_time_closed": stats.MaxIdleTimeClosed,
+		"max_lifetime_closed":  stats.MaxLifetimeClosed,
+	})
+}
+
+// notFoundHandler answers unregistered paths with a JSON 404
+func notFoundHandler(c *gin.Context) {
+	respondErrorWithDetails(c, http.StatusNotFound, CodeNotFound, "not found", gin.H{"path": c.Request.URL.Path})
+}
+
+// methodNotAllowedHandler answers registered paths requested with an unsupported method with a JSON 405
+func methodNotAllowedHandler(c *gin.Context) {
+	respondErrorWithDetails(c, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed", gin.H{"method": c.Request.Method, "path": c.Request.URL.Path})
 }
\ No newline at end of file
@@ -0,0 +1,100 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"postal-api/internal/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSearchExpandsStreetPrefixes(t *testing.T) {
+	if _, err := os.Stat(testDBPath); err != nil {
+		t.Skip("Database file postal_codes.db not found")
+	}
+	if err := database.InitializeWithPath(testDBPath); err != nil {
+		t.Fatalf("failed to initialize database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router)
+
+	tests := []struct {
+		city, street string
+		want         string
+	}{
+		{"Warszawa", "al. Jerozolimskie", "Aleje Jerozolimskie"},
+		{"Kraków", "ul. Floriańska", "Floriańska"},
+	}
+	for _, tt := range tests {
+		query := url.Values{"city": {tt.city}, "street": {tt.street}, "exact": {"true"}, "fallback": {"false"}}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/postal-codes?"+query.Encode(), nil))
+
+		var response struct {
+			Count   int `json:"count"`
+			Results []struct {
+				Street string `json:"street"`
+			} `json:"results"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if recorder.Code != http.StatusOK || response.Count == 0 {
+			t.Errorf("street=%s&exact=true: expected results, got %d %s", tt.street, recorder.Code, recorder.Body.String())
+			continue
+		}
+		for _, result := range response.Results {
+			if result.Street != tt.want {
+				t.Errorf("street=%s&exact=true: expected only %s, got %s", tt.street, tt.want, result.Street)
+			}
+		}
+	}
+}
+
+func TestSearchRejectsGroupByWithDedupeOrFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router)
+
+	for _, extra := range []string{"dedupe=true", "fields=postal_code,city"} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/postal-codes?city=Kraków&group_by=postal_code&"+extra, nil))
+
+		var response ErrorResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if recorder.Code != http.StatusUnprocessableEntity || response.Code != CodeValidation {
+			t.Errorf("group_by with %s: expected 422, got %d %s", extra, recorder.Code, recorder.Body.String())
+		}
+	}
+}
+
+func TestSearchRejectsReshapingWithNDJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterRoutes(router)
+
+	for _, param := range []string{"group_by=postal_code", "dedupe=true"} {
+		request := httptest.NewRequest(http.MethodGet, "/postal-codes?city=Kraków&"+param, nil)
+		request.Header.Set("Accept", ndjsonContentType)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		var response ErrorResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if recorder.Code != http.StatusUnprocessableEntity || response.Code != CodeValidation {
+			t.Errorf("%s with NDJSON: expected 422, got %d %s", param, recorder.Code, recorder.Body.String())
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"postal-api/internal/database"
+	"postal-api/internal/services"
+	"postal-api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ndjsonContentType is the media type of newline-delimited JSON
+const ndjsonContentType = "application/x-ndjson"
+
+// ndjsonFlushInterval is the number of lines written between flushes to the client
+const ndjsonFlushInterval = 100
+
+// searchTypeHeader reports which search tier produced streamed results
+const searchTypeHeader = "X-Search-Type"
+
+// wantsNDJSON reports whether the client prefers newline-delimited JSON over a JSON array
+func wantsNDJSON(c *gin.Context) bool {
+	return prefersOverJSON(c, ndjsonContentType)
+}
+
+// isUnlimitedExport reports whether the request is a limit=0 NDJSON search streaming every matching row
+func isUnlimitedExport(c *gin.Context) bool {
+	return c.FullPath() == "/postal-codes" && trimParam(c.Query("limit")) == "0" && wantsNDJSON(c)
+}
+
+// streamSearchResults writes each search result as one JSON object per line while the rows are read.
+// The search type goes into the X-Search-Type header since there is no response envelope.
+func streamSearchResults(c *gin.Context, params utils.SearchParams, fields []string) {
+	encoder := json.NewEncoder(c.Writer)
+	written := 0
+
+	searchType, err := services.StreamSearch(c.Request.Context(), params, func(searchType string, row database.PostalCode) error {
+		if written == 0 {
+			c.Header(searchTypeHeader, searchType)
+			c.Header("Content-Type", ndjsonContentType)
+			c.Status(http.StatusOK)
+		}
+
+		var line interface{} = row
+		if len(fields) > 0 {
+			line = row.SelectFields(fields)
+		}
+		if err := encoder.Encode(line); err != nil {
+			return err
+		}
+
+		written++
+		if written%ndjsonFlushInterval == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+
+	if err != nil {
+		if written == 0 {
+			respondServiceError(c, err)
+			return
+		}
+		// The status line is already sent, so a final error line tells the client the stream is incomplete
+		requestID := c.GetString("request_id")
+		log.Printf("[%s] %s %s stream aborted after %d rows: %v", requestID, c.Request.Method, c.Request.URL.Path, written, err)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		abort := ErrorResponse{Error: "Stream aborted", Code: CodeInternal, Details: gin.H{"request_id": requestID, "rows": written}}
+		if errors.Is(err, context.DeadlineExceeded) {
+			abort.Error, abort.Code = "Database query timed out", CodeTimeout
+		}
+		encoder.Encode(abort)
+		c.Writer.Flush()
+		return
+	}
+
+	if written == 0 {
+		c.Header(searchTypeHeader, searchType)
+		c.Data(http.StatusOK, ndjsonContentType, nil)
+		return
+	}
+	c.Writer.Flush()
+}
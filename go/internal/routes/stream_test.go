@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestUnlimitedExportUsesExportTimeout(t *testing.T) {
+	t.Setenv("QUERY_TIMEOUT_MS", "1000")
+	t.Setenv("EXPORT_TIMEOUT_MS", "600000")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(queryTimeoutMiddleware())
+	var remaining time.Duration
+	router.GET("/postal-codes", func(c *gin.Context) {
+		deadline, _ := c.Request.Context().Deadline()
+		remaining = time.Until(deadline)
+	})
+
+	tests := []struct {
+		query  string
+		accept string
+		export bool
+	}{
+		{"limit=0", ndjsonContentType, true},
+		{"limit=0", "", false},
+		{"limit=10", ndjsonContentType, false},
+	}
+	for _, tt := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/postal-codes?city=Kraków&"+tt.query, nil)
+		if tt.accept != "" {
+			request.Header.Set("Accept", tt.accept)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), request)
+
+		if export := remaining > time.Minute; export != tt.export {
+			t.Errorf("%s with Accept %q: expected export timeout %v, got %s remaining", tt.query, tt.accept, tt.export, remaining)
+		}
+	}
+}
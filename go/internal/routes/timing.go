@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseTimeHeader reports how long the server spent on a request, in milliseconds
+const responseTimeHeader = "X-Response-Time"
+
+// timedWriter sets the response time header just before the headers are sent, since headers
+// cannot change once the body has started
+type timedWriter struct {
+	gin.ResponseWriter
+	start time.Time
+}
+
+// setResponseTime adds the elapsed time unless the headers have already been written
+func (w *timedWriter) setResponseTime() {
+	if w.Written() {
+		return
+	}
+	elapsed := float64(time.Since(w.start).Microseconds()) / 1000
+	w.Header().Set(responseTimeHeader, strconv.FormatFloat(elapsed, 'f', 3, 64)+"ms")
+}
+
+func (w *timedWriter) WriteHeaderNow() {
+	w.setResponseTime()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timedWriter) Write(data []byte) (int, error) {
+	w.setResponseTime()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timedWriter) WriteString(s string) (int, error) {
+	w.setResponseTime()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *timedWriter) Flush() {
+	w.setResponseTime()
+	w.ResponseWriter.Flush()
+}
+
+// responseTimeMiddleware sets X-Response-Time on every response, including errors. Streamed
+// responses report the time until their first byte.
+func responseTimeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &timedWriter{ResponseWriter: c.Writer, start: time.Now()}
+		c.Writer = writer
+		c.Next()
+
+		// Responses without a body, such as 304, are written by gin after the handlers return
+		writer.setResponseTime()
+	}
+}
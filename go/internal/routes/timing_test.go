@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseTimeRe matches a millisecond duration such as 0.042ms
+var responseTimeRe = regexp.MustCompile(`^\d+\.\d{3}ms$`)
+
+func TestResponseTimeMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(responseTimeMiddleware())
+	router.GET("/ok", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"status": "ok"}) })
+	router.GET("/error", func(c *gin.Context) { respondError(c, http.StatusInternalServerError, CodeInternal, "boom") })
+	router.GET("/not-modified", func(c *gin.Context) { c.Status(http.StatusNotModified) })
+	router.GET("/stream", func(c *gin.Context) {
+		c.Writer.WriteString("line\n")
+		c.Writer.Flush()
+	})
+
+	for _, path := range []string{"/ok", "/error", "/not-modified", "/stream", "/missing"} {
+		t.Run(path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			if value := recorder.Header().Get(responseTimeHeader); !responseTimeRe.MatchString(value) {
+				t.Errorf("expected a millisecond %s header, got %q (status %d)", responseTimeHeader, value, recorder.Code)
+			}
+		})
+	}
+}
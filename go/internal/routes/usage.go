@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"net/http"
+
+	"postal-api/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getUsageHandler reports per-key request counts since the last reset
+func getUsageHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, auth.Usage.Snapshot(false))
+}
+
+// resetUsageHandler reports per-key request counts and starts counting from zero
+func resetUsageHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, auth.Usage.Snapshot(true))
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"postal-api/internal/auth"
+)
+
+func TestAPIKeyMiddlewareRecordsUsage(t *testing.T) {
+	previous := auth.Usage
+	auth.Usage = auth.NewUsageCounter()
+	t.Cleanup(func() { auth.Usage = previous })
+
+	router := newAuthRouter([]string{"secret"})
+	for _, key := range []string{"secret", "secret", "guess"} {
+		request := httptest.NewRequest(http.MethodGet, "/postal-codes", nil)
+		request.Header.Set(apiKeyHeader, key)
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	usage := auth.Usage.Snapshot(false)
+	if len(usage.Keys) != 1 || usage.Keys[0].Requests != 2 {
+		t.Errorf("expected 2 requests for the valid key only, got %+v", usage.Keys)
+	}
+}
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"postal-api/internal/i18n"
+	"postal-api/internal/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldError describes why a single request parameter was rejected
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// paramValidator parses query parameters and accumulates every invalid field,
+// so a single response can report all of them instead of stopping at the first
+type paramValidator struct {
+	c      *gin.Context
+	lang   string
+	errors []FieldError
+}
+
+// newParamValidator creates a validator for the request's query parameters, reporting in the request's language
+func newParamValidator(c *gin.Context) *paramValidator {
+	return &paramValidator{c: c, lang: i18n.FromContext(c.Request.Context())}
+}
+
+// fail records an invalid parameter with a localized reason built from an i18n message ID
+func (v *paramValidator) fail(field, messageID string, args ...interface{}) {
+	v.errors = append(v.errors, FieldError{Field: field, Reason: i18n.Translate(v.lang, messageID, args...)})
+}
+
+// failFields records the invalid parameters reported by the shared request validation
+func (v *paramValidator) failFields(fieldErrors []request.FieldError) {
+	for _, fieldError := range fieldErrors {
+		v.fail(fieldError.Field, fieldError.MessageID, fieldError.Args...)
+	}
+}
+
+// positiveInt parses an optional positive integer parameter, returning the default when it is absent
+func (v *paramValidator) positiveInt(name string, defaultValue int) int {
+	value := trimParam(v.c.Query(name))
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 1 {
+		v.fail(name, i18n.MsgPositiveInteger)
+		return defaultValue
+	}
+	return parsed
+}
+
+// nonNegativeInt parses an optional non-negative integer parameter, returning the default when it is absent
+func (v *paramValidator) nonNegativeInt(name string, defaultValue int) int {
+	value := trimParam(v.c.Query(name))
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		v.fail(name, i18n.MsgNonNegativeInteger)
+		return defaultValue
+	}
+	return parsed
+}
+
+// boolean parses an optional true/false parameter, returning the default when it is absent
+func (v *paramValidator) boolean(name string, defaultValue bool) bool {
+	switch trimParam(v.c.Query(name)) {
+	case "":
+		return defaultValue
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	v.fail(name, i18n.MsgOneOf, "true, false")
+	return defaultValue
+}
+
+// float parses a required numeric parameter
+func (v *paramValidator) float(name string) float64 {
+	value := trimParam(v.c.Query(name))
+	if value == "" {
+		v.fail(name, i18n.MsgRequired)
+		return 0
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		v.fail(name, i18n.MsgNumber)
+		return 0
+	}
+	return parsed
+}
+
+// required returns a parameter that must be present after trimming
+func (v *paramValidator) required(name string) string {
+	value := trimParam(v.c.Query(name))
+	if value == "" {
+		v.fail(name, i18n.MsgRequired)
+	}
+	return value
+}
+
+// respondIfInvalid writes a 422 listing every invalid field and reports whether the request was rejected
+func (v *paramValidator) respondIfInvalid() bool {
+	if len(v.errors) == 0 {
+		return false
+	}
+
+	fields := make([]string, len(v.errors))
+	for i, fieldError := range v.errors {
+		fields[i] = fieldError.Field
+	}
+	v.c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+		Error:   i18n.Translate(v.lang, i18n.MsgInvalidParameters, strings.Join(fields, ", ")),
+		Code:    CodeValidation,
+		Details: v.errors,
+	})
+	return true
+}
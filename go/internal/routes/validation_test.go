@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParamValidatorReportsEveryInvalidField(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?limit=abc&offset=-1&lat=north", nil)
+
+	v := newParamValidator(c)
+	v.positiveInt("limit", 10)
+	v.nonNegativeInt("offset", 0)
+	v.float("lat")
+	v.required("range")
+	if !v.respondIfInvalid() {
+		t.Fatal("expected the request to be rejected")
+	}
+
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", recorder.Code)
+	}
+
+	var body struct {
+		Code    string       `json:"code"`
+		Details []FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if body.Code != CodeValidation {
+		t.Errorf("expected code %s, got %s", CodeValidation, body.Code)
+	}
+
+	want := []string{"limit", "offset", "lat", "range"}
+	if len(body.Details) != len(want) {
+		t.Fatalf("expected %d field errors, got %+v", len(want), body.Details)
+	}
+	for i, field := range want {
+		if body.Details[i].Field != field {
+			t.Errorf("detail %d: expected field %s, got %s", i, field, body.Details[i].Field)
+		}
+	}
+}
+
+func TestParamValidatorAcceptsValidParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?limit=25&offset=0", nil)
+
+	v := newParamValidator(c)
+	if limit := v.positiveInt("limit", 10); limit != 25 {
+		t.Errorf("expected limit 25, got %d", limit)
+	}
+	if offset := v.nonNegativeInt("offset", 5); offset != 0 {
+		t.Errorf("expected offset 0, got %d", offset)
+	}
+	if v.respondIfInvalid() {
+		t.Fatalf("expected no validation errors, got %+v", v.errors)
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"postal-api/internal/database"
+	"postal-api/internal/utils"
+)
+
+// AddressValidation is the verdict of ValidateAddress
+type AddressValidation struct {
+	Valid bool `json:"valid"`
+	// Exact is set when the address matched as typed, without Polish character normalization
+	Exact      bool   `json:"exact"`
+	PostalCode string `json:"postal_code,omitempty"`
+}
+
+// ValidateAddress reports whether a city, optional street and optional house number exist. The city and street
+// must equal the stored names, compared in SQL so the indexes apply: first as typed, then Polish-normalized.
+// Street prefixes such as "ul." are ignored on both sides. Without a house number a single row settles it;
+// with one, the rows of that street are checked in Go, which only reads the street's own ranges.
+func ValidateAddress(ctx context.Context, city, street, houseNumber string) (*AddressValidation, error) {
+	for _, normalized := range []bool{false, true} {
+		query, args := buildAddressQuery(city, street, houseNumber == "", normalized)
+		postalCode, err := findAddress(ctx, query, args, houseNumber)
+		if err != nil {
+			return nil, err
+		}
+		if postalCode != "" {
+			return &AddressValidation{Valid: true, Exact: !normalized, PostalCode: postalCode}, nil
+		}
+	}
+	return &AddressValidation{}, nil
+}
+
+// buildAddressQuery selects the records of a city and street by equality on the plain or normalized columns,
+// limited to one row when single is set
+func buildAddressQuery(city, street string, single, normalized bool) (string, []interface{}) {
+	cityCol, streetCol := "city_clean", "street"
+	if normalized {
+		cityCol, streetCol = "city_normalized", "street_normalized"
+		city = utils.NormalizePolishText(city)
+	}
+
+	query := "SELECT " + database.PostalCodeColumns() + " FROM postal_codes WHERE " + cityCol + " = ? COLLATE NOCASE"
+	args := []interface{}{city}
+	if street != "" {
+		condition, streetArgs := streetNameCondition(streetCol, street, normalized)
+		query += condition
+		args = append(args, streetArgs...)
+	}
+
+	query += " ORDER BY postal_code, id"
+	if single {
+		query += " LIMIT 1"
+	}
+	return query, args
+}
+
+// streetNameCondition matches column against a street name bare or with any of utils.StreetPrefixes, as some
+// names are stored like "pl. Wolności", ignoring case and the prefix the name was given with. Normalized columns
+// are compared with the Polish-normalized name.
+func streetNameCondition(column, street string, normalized bool) (string, []interface{}) {
+	name := utils.StripStreetPrefix(street)
+	variants := []string{name}
+	for _, prefix := range utils.StreetPrefixes {
+		variants = append(variants, prefix+". "+name)
+	}
+	if normalized {
+		for i, variant := range variants {
+			variants[i] = utils.NormalizePolishText(variant)
+		}
+	}
+	return matchAnyCondition(column, variants, matchWhole, false)
+}
+
+// findAddress returns the postal code of the first record of the query covering houseNumber, or of the first
+// record when houseNumber is empty, and "" when there is none
+func findAddress(ctx context.Context, query string, args []interface{}, houseNumber string) (string, error) {
+	defer logSlowQuery(time.Now(), query, args)
+	rows, err := database.GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row, err := database.ScanPostalCode(rows)
+		if err != nil {
+			return "", fmt.Errorf("failed to scan row: %w", err)
+		}
+		if houseNumber == "" || matchHouseNumber(&row, houseNumber, false) {
+			return row.PostalCode, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return "", nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"postal-api/internal/database"
+)
+
+// DuplicateGroup is a set of rows identical in every stored column except id. Every column but postal_code
+// is nullable, and rows missing the same value still group together.
+type DuplicateGroup struct {
+	PostalCode   string  `json:"postal_code"`
+	City         *string `json:"city"`
+	Street       *string `json:"street,omitempty"`
+	HouseNumbers *string `json:"house_numbers,omitempty"`
+	Municipality *string `json:"municipality,omitempty"`
+	County       *string `json:"county,omitempty"`
+	Province     *string `json:"province"`
+	Count        int     `json:"count"`
+}
+
+// DuplicatesResponse represents a page of duplicate row groups
+type DuplicatesResponse struct {
+	Groups []DuplicateGroup `json:"groups"`
+	Count  int              `json:"count"`
+	// Total is the number of duplicate groups; ExtraRows the number of rows that could be removed
+	Total     int `json:"total"`
+	ExtraRows int `json:"extra_rows"`
+	Limit     int `json:"limit"`
+	Offset    int `json:"offset"`
+}
+
+// duplicateColumns are the source columns compared to find duplicates; the derived columns follow from them
+const duplicateColumns = "postal_code, city, street, house_numbers, municipality, county, province"
+
+// FindDuplicates returns groups of rows that repeat the same record, largest groups first
+func FindDuplicates(ctx context.Context, limit, offset int) (*DuplicatesResponse, error) {
+	db := database.GetDB()
+	grouped := "SELECT " + duplicateColumns + ", COUNT(*) AS row_count FROM postal_codes GROUP BY " + duplicateColumns + " HAVING COUNT(*) > 1"
+
+	response := &DuplicatesResponse{Groups: []DuplicateGroup{}, Limit: limit, Offset: offset}
+	totalQuery := "SELECT COUNT(*), COALESCE(SUM(row_count - 1), 0) FROM (" + grouped + ") AS duplicates"
+	start := time.Now()
+	if err := db.QueryRowContext(ctx, totalQuery).Scan(&response.Total, &response.ExtraRows); err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	logSlowQuery(start, totalQuery, nil)
+
+	query := grouped + " ORDER BY row_count DESC, " + duplicateColumns + " LIMIT ? OFFSET ?"
+	args := []interface{}{limit, offset}
+	defer logSlowQuery(time.Now(), query, args)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var group DuplicateGroup
+		if err := rows.Scan(&group.PostalCode, &group.City, &group.Street, &group.HouseNumbers,
+			&group.Municipality, &group.County, &group.Province, &group.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		response.Groups = append(response.Groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	response.Count = len(response.Groups)
+	return response, nil
+}
+
+// ClearCaches drops every cached location list, region, statistic and record count
+func ClearCaches() {
+	provincesCache.Clear()
+	countiesCache.Clear()
+	municipalitiesCache.Clear()
+	citiesCache.Clear()
+	multiCodeCache.Clear()
+	streetsCache.Clear()
+	detailedStreetsCache.Clear()
+	distinctCitiesCache.Clear()
+	phoneticCitiesCache.Clear()
+	distinctValuesCache.Clear()
+	regionCache.Clear()
+	recordCountCache.Clear()
+	streetsPerCityCache.Clear()
+	metaCache.Clear()
+
+	statsMu.Lock()
+	statsCache = nil
+	statsMu.Unlock()
+}
+
+// ReloadDatabase reopens the database to pick up a replaced postal_codes.db and clears the caches,
+// since they hold results from the previous file
+func ReloadDatabase() error {
+	if err := database.Reload(); err != nil {
+		return fmt.Errorf("database reload failed: %w", err)
+	}
+	ClearCaches()
+	return nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"postal-api/internal/database"
+)
+
+// useTempDatabase switches the services to a new SQLite database holding the given rows of
+// (postal_code, city, street, province) for the duration of the test
+func useTempDatabase(t *testing.T, rows [][]interface{}) {
+	path := filepath.Join(t.TempDir(), "postal_codes.db")
+	db, err := sql.Open(database.DriverSQLite, path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	schema := `CREATE TABLE postal_codes (id INTEGER PRIMARY KEY AUTOINCREMENT, postal_code TEXT NOT NULL, city TEXT,
+		street TEXT, house_numbers TEXT, municipality TEXT, county TEXT, province TEXT, city_normalized TEXT,
+		street_normalized TEXT, city_clean TEXT, population INTEGER)`
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	for _, row := range rows {
+		if _, err := db.Exec("INSERT INTO postal_codes (postal_code, city, street, province) VALUES (?, ?, ?, ?)", row...); err != nil {
+			t.Fatalf("failed to insert row: %v", err)
+		}
+	}
+
+	database.Close()
+	if err := database.InitializeWithPath(path); err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+}
+
+func TestFindDuplicates(t *testing.T) {
+	useTempDatabase(t, [][]interface{}{
+		{"00-001", "Warszawa", "Długa", "mazowieckie"},
+		{"00-001", "Warszawa", "Długa", "mazowieckie"},
+		{"00-001", "Warszawa", "Długa", "mazowieckie"},
+		{"00-002", "Warszawa", nil, "mazowieckie"},
+		{"00-002", "Warszawa", nil, "mazowieckie"},
+		{"00-003", "Warszawa", "Krótka", "mazowieckie"},
+		{"00-004", nil, nil, nil},
+		{"00-004", nil, nil, nil},
+	})
+
+	response, err := FindDuplicates(context.Background(), 10, 0)
+	if err != nil {
+		t.Fatalf("FindDuplicates failed: %v", err)
+	}
+	if response.Total != 3 || response.ExtraRows != 4 || response.Count != 3 {
+		t.Fatalf("expected 3 groups with 4 extra rows, got %+v", response)
+	}
+	if first := response.Groups[0]; first.PostalCode != "00-001" || first.Count != 3 {
+		t.Errorf("expected the largest group first, got %+v", first)
+	}
+	if second := response.Groups[1]; second.Street != nil || second.Count != 2 {
+		t.Errorf("expected rows without a street to be grouped, got %+v", second)
+	}
+	if third := response.Groups[2]; third.City != nil || third.Province != nil || third.Count != 2 {
+		t.Errorf("expected rows without a city and province to be grouped, got %+v", third)
+	}
+
+	page, err := FindDuplicates(context.Background(), 1, 1)
+	if err != nil {
+		t.Fatalf("FindDuplicates failed: %v", err)
+	}
+	if page.Count != 1 || page.Total != 3 || page.Groups[0].PostalCode != "00-002" {
+		t.Errorf("unexpected second page %+v", page)
+	}
+}
+
+func TestClearCachesDropsResultsOfThePreviousDatabase(t *testing.T) {
+	openTestDatabase(t)
+	if _, err := GetProvinces(context.Background(), nil, false); err != nil {
+		t.Fatalf("GetProvinces failed: %v", err)
+	}
+	if _, err := GetRecordCount(context.Background()); err != nil {
+		t.Fatalf("GetRecordCount failed: %v", err)
+	}
+
+	useTempDatabase(t, [][]interface{}{{"00-001", "Warszawa", "Długa", "mazowieckie"}})
+	t.Cleanup(ClearCaches)
+
+	cached, err := GetProvinces(context.Background(), nil, false)
+	if err != nil {
+		t.Fatalf("GetProvinces failed: %v", err)
+	}
+	if cached.Count == 1 {
+		t.Fatal("expected the provinces of the previous database to still be cached")
+	}
+
+	ClearCaches()
+	provinces, err := GetProvinces(context.Background(), nil, false)
+	if err != nil {
+		t.Fatalf("GetProvinces failed: %v", err)
+	}
+	if provinces.Count != 1 || provinces.Provinces[0] != "mazowieckie" {
+		t.Errorf("expected only mazowieckie after clearing the caches, got %+v", provinces)
+	}
+	if count, err := GetRecordCount(context.Background()); err != nil || count != 1 {
+		t.Errorf("expected 1 record after clearing the caches, got %d (%v)", count, err)
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+
+	"postal-api/internal/database"
+)
+
+// DebugQuery describes one SQL query run while answering a search
+type DebugQuery struct {
+	Tier string        `json:"tier"`
+	SQL  string        `json:"sql"`
+	Args []interface{} `json:"args"`
+	Rows int           `json:"rows"`
+	Plan []string      `json:"plan,omitempty"`
+}
+
+// DebugInfo collects the queries behind a search response and the tier that produced its results
+type DebugInfo struct {
+	Tier    string       `json:"tier"`
+	Queries []DebugQuery `json:"queries"`
+
+	// withPlan runs EXPLAIN QUERY PLAN for every recorded query
+	withPlan bool
+}
+
+// debugKey is the context key holding the DebugInfo being collected
+type debugKey struct{}
+
+// WithDebug returns a context that collects search queries into the returned DebugInfo,
+// including their SQLite query plans when withPlan is set
+func WithDebug(ctx context.Context, withPlan bool) (context.Context, *DebugInfo) {
+	info := &DebugInfo{Queries: []DebugQuery{}, withPlan: withPlan}
+	return context.WithValue(ctx, debugKey{}, info), info
+}
+
+// debugFromContext returns the DebugInfo being collected, or nil when debugging is off
+func debugFromContext(ctx context.Context) *DebugInfo {
+	info, _ := ctx.Value(debugKey{}).(*DebugInfo)
+	return info
+}
+
+// recordQuery adds a query and its row count to the DebugInfo in the context, if any
+func recordQuery(ctx context.Context, tier, query string, args []interface{}, rows int) error {
+	info := debugFromContext(ctx)
+	if info == nil {
+		return nil
+	}
+
+	debugQuery := DebugQuery{Tier: tier, SQL: database.GetDB().Rebind(query), Args: args, Rows: rows}
+	if info.withPlan {
+		plan, err := database.GetDB().ExplainQueryPlan(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		debugQuery.Plan = plan
+	}
+	info.Queries = append(info.Queries, debugQuery)
+	return nil
+}
+
+// recordTier sets the tier that produced the results on the DebugInfo in the context, if any
+func recordTier(ctx context.Context, tier string) {
+	if info := debugFromContext(ctx); info != nil {
+		info.Tier = tier
+	}
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"postal-api/internal/cache"
+	"postal-api/internal/config"
+)
+
+// distinctColumns whitelists the columns served by GetDistinctValues and maps them to database columns.
+// Only these names ever reach the SQL text, so the column parameter cannot inject anything.
+var distinctColumns = map[string]string{
+	"province":     "province",
+	"county":       "county",
+	"municipality": "municipality",
+	"city":         "city_clean",
+	"street":       "street",
+	"postal_code":  "postal_code",
+}
+
+// distinctValuesCache holds pages of distinct column values, keyed by column and list options
+var distinctValuesCache = cache.New[*DistinctValuesResponse](config.LocationCacheTTL())
+
+// DistinctValuesResponse represents one page of the distinct values of a column
+type DistinctValuesResponse struct {
+	Column string   `json:"column"`
+	Values []string `json:"values"`
+	Count  int      `json:"count"`
+	Total  int      `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+}
+
+// DistinctColumns returns the column names accepted by GetDistinctValues, sorted
+func DistinctColumns() []string {
+	columns := make([]string, 0, len(distinctColumns))
+	for column := range distinctColumns {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+	return columns
+}
+
+// GetDistinctValues gets the sorted distinct non-empty values of a whitelisted column, paged by the list options
+func GetDistinctValues(ctx context.Context, column string, opts ListOptions) (*DistinctValuesResponse, error) {
+	dbColumn, ok := distinctColumns[column]
+	if !ok {
+		return nil, fmt.Errorf("column %q is not allowed", column)
+	}
+
+	key := column + opts.cacheKey()
+	if cached, ok := distinctValuesCache.Get(key); ok {
+		return cached, nil
+	}
+
+	from := fmt.Sprintf("FROM postal_codes WHERE %s IS NOT NULL AND %s != ''", dbColumn, dbColumn)
+	values, total, err := queryLocationPage(ctx, dbColumn, from, nil, dbColumn, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DistinctValuesResponse{
+		Column: column,
+		Values: values,
+		Count:  len(values),
+		Total:  total,
+		Limit:  opts.Limit,
+		Offset: opts.Offset,
+	}
+	distinctValuesCache.Set(key, response)
+	return response, nil
+}
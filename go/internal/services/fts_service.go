@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"postal-api/internal/database"
+	"postal-api/internal/i18n"
+	"postal-api/internal/utils"
+)
+
+// maxFullTextTokens caps how many words of a full-text query are used
+const maxFullTextTokens = 5
+
+// FullTextResult is a postal code record with its relevance score; higher scores are more relevant
+type FullTextResult struct {
+	database.PostalCode
+	Score float64 `json:"score"`
+}
+
+// FullTextResponse represents the response for full-text searches
+type FullTextResponse struct {
+	Results    []FullTextResult `json:"results"`
+	Count      int              `json:"count"`
+	Query      string           `json:"query"`
+	SearchType string           `json:"search_type"`
+	Message    string           `json:"message,omitempty"`
+}
+
+// splitWords splits text into words of letters and digits
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// fullTextTokens splits a query into at most maxFullTextTokens words
+func fullTextTokens(query string) []string {
+	tokens := splitWords(query)
+	if len(tokens) > maxFullTextTokens {
+		tokens = tokens[:maxFullTextTokens]
+	}
+	return tokens
+}
+
+// buildMatchExpression turns tokens into an FTS5 query requiring every Polish-normalized token as a prefix.
+// Tokens only hold letters and digits, so quoting them cannot inject FTS5 syntax.
+func buildMatchExpression(tokens []string) string {
+	terms := make([]string, len(tokens))
+	for i, token := range tokens {
+		terms[i] = fmt.Sprintf(`"%s"*`, utils.NormalizePolishText(token))
+	}
+	return strings.Join(terms, " ")
+}
+
+// SearchFullText searches city and street names with the FTS5 index, ranked by bm25,
+// or with LIKE matching and a simple word score when the index is not available
+func SearchFullText(ctx context.Context, query string, limit int) (*FullTextResponse, error) {
+	tokens := fullTextTokens(query)
+	response := &FullTextResponse{Results: []FullTextResult{}, Query: query}
+	if len(tokens) == 0 {
+		response.SearchType = "fts"
+		return response, nil
+	}
+
+	var err error
+	if database.HasFTS() {
+		response.SearchType = "fts"
+		response.Results, err = searchFTSIndex(ctx, tokens, limit)
+	} else {
+		response.SearchType = "like_fallback"
+		response.Message = i18n.TranslateContext(ctx, i18n.MsgFullTextUnavailable)
+		response.Results, err = searchLikeFallback(ctx, tokens, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response.Count = len(response.Results)
+	return response, nil
+}
+
+// searchFTSIndex queries the FTS5 index; bm25 is negated so that higher scores rank first
+func searchFTSIndex(ctx context.Context, tokens []string, limit int) ([]FullTextResult, error) {
+	query := fmt.Sprintf(`SELECT %s, -bm25(%s) AS score FROM %s
+		JOIN postal_codes p ON p.id = %s.rowid
+		WHERE %s MATCH ? ORDER BY score DESC, p.id LIMIT ?`,
+		database.PostalCodeColumnsFor("p"), database.FTSTable, database.FTSTable, database.FTSTable, database.FTSTable)
+
+	rows, err := database.GetDB().QueryContext(ctx, query, buildMatchExpression(tokens), limit)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	results := []FullTextResult{}
+	for rows.Next() {
+		var result FullTextResult
+		result.PostalCode, err = database.ScanPostalCode(rows, &result.Score)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return results, nil
+}
+
+// searchLikeFallback requires every token in the normalized city or street and scores whole-word matches higher
+func searchLikeFallback(ctx context.Context, tokens []string, limit int) ([]FullTextResult, error) {
+	normalized := make([]string, len(tokens))
+	query := "SELECT " + database.PostalCodeColumns() + " FROM postal_codes WHERE 1=1"
+	var args []interface{}
+	for i, token := range tokens {
+		normalized[i] = strings.ToLower(utils.NormalizePolishText(token))
+		query += " AND (city_normalized LIKE ? COLLATE NOCASE OR street_normalized LIKE ? COLLATE NOCASE)"
+		args = append(args, "%"+normalized[i]+"%", "%"+normalized[i]+"%")
+	}
+	query += " ORDER BY population DESC, id LIMIT ?"
+	args = append(args, min(limit*5, 1000))
+
+	rows, err := database.GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	records, err := database.ScanPostalCodes(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	results := make([]FullTextResult, 0, len(records))
+	for _, record := range records {
+		results = append(results, FullTextResult{PostalCode: record, Score: likeScore(record, normalized)})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// likeScore gives 2 points per token equal to a word of the city or street, 1 point per token starting a word
+// and nothing for substring matches, so "Dluga" ranks "Długa" above "Długosza" and "Niedługa"
+func likeScore(record database.PostalCode, tokens []string) float64 {
+	text := record.City
+	if record.Street != nil {
+		text += " " + *record.Street
+	}
+	words := splitWords(strings.ToLower(utils.NormalizePolishText(text)))
+
+	score := 0.0
+	for _, token := range tokens {
+		best := 0.0
+		for _, word := range words {
+			if word == token {
+				best = 2
+				break
+			}
+			if strings.HasPrefix(word, token) {
+				best = 1
+			}
+		}
+		score += best
+	}
+	return score
+}
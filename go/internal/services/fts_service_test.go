@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"postal-api/internal/database"
+)
+
+func TestBuildMatchExpressionQuotesNormalizedTokens(t *testing.T) {
+	tokens := fullTextTokens(`Łódź "Piotrkowska" OR*`)
+	if got, want := buildMatchExpression(tokens), `"Lodz"* "Piotrkowska"* "OR"*`; got != want {
+		t.Errorf("buildMatchExpression = %q, want %q", got, want)
+	}
+}
+
+func TestFullTextTokensCapsWords(t *testing.T) {
+	if tokens := fullTextTokens("a b c d e f g"); len(tokens) != maxFullTextTokens {
+		t.Errorf("expected %d tokens, got %v", maxFullTextTokens, tokens)
+	}
+}
+
+func TestLikeScorePrefersWholeWords(t *testing.T) {
+	exact := database.PostalCode{City: "Kraków", Street: strPtr("Długa")}
+	prefix := database.PostalCode{City: "Kraków", Street: strPtr("Długosza")}
+	substring := database.PostalCode{City: "Kraków", Street: strPtr("Niedługa")}
+
+	tokens := []string{"dlug"}
+	if likeScore(prefix, tokens) <= likeScore(substring, tokens) {
+		t.Error("expected a word prefix to outscore a substring match")
+	}
+
+	tokens = []string{"dluga"}
+	if likeScore(exact, tokens) <= likeScore(substring, tokens) {
+		t.Error("expected a whole word to outscore a substring match")
+	}
+}
+
+func TestSearchFullTextRanksWholeWordsFirst(t *testing.T) {
+	openTestDatabase(t)
+	response, err := SearchFullText(context.Background(), "dluga krakow", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Count == 0 {
+		t.Fatal("expected results for 'dluga krakow'")
+	}
+	if street := strValue(response.Results[0].Street); street != "Długa" {
+		t.Errorf("expected Długa first, got %q", street)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"postal-api/internal/database"
+)
+
+// StreetNode is a street with its house number range within a hierarchy
+type StreetNode struct {
+	Street       string  `json:"street"`
+	HouseNumbers *string `json:"house_numbers,omitempty"`
+}
+
+// CityNode groups streets under a city
+type CityNode struct {
+	City    string       `json:"city"`
+	Streets []StreetNode `json:"streets,omitempty"`
+}
+
+// MunicipalityNode groups cities under a municipality; Municipality is null when unknown
+type MunicipalityNode struct {
+	Municipality *string    `json:"municipality"`
+	Cities       []CityNode `json:"cities"`
+}
+
+// CountyNode groups municipalities under a county; County is null when unknown
+type CountyNode struct {
+	County         *string            `json:"county"`
+	Municipalities []MunicipalityNode `json:"municipalities"`
+}
+
+// ProvinceNode groups counties under a province
+type ProvinceNode struct {
+	Province string       `json:"province"`
+	Counties []CountyNode `json:"counties"`
+}
+
+// HierarchyResponse represents a postal code lookup expanded into its administrative hierarchy
+type HierarchyResponse struct {
+	PostalCode string         `json:"postal_code"`
+	Count      int            `json:"count"`
+	Hierarchy  []ProvinceNode `json:"hierarchy"`
+}
+
+// sameOptional compares two optional values, treating nil and empty as equal
+func sameOptional(a, b *string) bool {
+	valueA, valueB := "", ""
+	if a != nil {
+		valueA = *a
+	}
+	if b != nil {
+		valueB = *b
+	}
+	return valueA == valueB
+}
+
+// BuildHierarchy nests flat postal code records as province → county → municipality → city → streets,
+// keeping the order in which each level first appears
+func BuildHierarchy(records []database.PostalCode) []ProvinceNode {
+	var provinces []ProvinceNode
+
+	for _, record := range records {
+		pi := -1
+		for i := range provinces {
+			if provinces[i].Province == record.Province {
+				pi = i
+				break
+			}
+		}
+		if pi < 0 {
+			provinces = append(provinces, ProvinceNode{Province: record.Province})
+			pi = len(provinces) - 1
+		}
+		province := &provinces[pi]
+
+		ci := -1
+		for i := range province.Counties {
+			if sameOptional(province.Counties[i].County, record.County) {
+				ci = i
+				break
+			}
+		}
+		if ci < 0 {
+			province.Counties = append(province.Counties, CountyNode{County: record.County})
+			ci = len(province.Counties) - 1
+		}
+		county := &province.Counties[ci]
+
+		mi := -1
+		for i := range county.Municipalities {
+			if sameOptional(county.Municipalities[i].Municipality, record.Municipality) {
+				mi = i
+				break
+			}
+		}
+		if mi < 0 {
+			county.Municipalities = append(county.Municipalities, MunicipalityNode{Municipality: record.Municipality})
+			mi = len(county.Municipalities) - 1
+		}
+		municipality := &county.Municipalities[mi]
+
+		ti := -1
+		for i := range municipality.Cities {
+			if municipality.Cities[i].City == record.City {
+				ti = i
+				break
+			}
+		}
+		if ti < 0 {
+			municipality.Cities = append(municipality.Cities, CityNode{City: record.City})
+			ti = len(municipality.Cities) - 1
+		}
+		city := &municipality.Cities[ti]
+
+		// Records without a street only contribute the city itself
+		if record.Street != nil && *record.Street != "" {
+			city.Streets = append(city.Streets, StreetNode{Street: *record.Street, HouseNumbers: record.HouseNumbers})
+		}
+	}
+
+	return provinces
+}
+
+// Depth levels supported by the location tree
+const (
+	TreeDepthCounties       = 1
+	TreeDepthMunicipalities = 2
+	TreeDepthCities         = 3
+)
+
+// TreeMunicipality is a municipality with its cities in the location tree
+type TreeMunicipality struct {
+	Municipality string   `json:"municipality"`
+	Cities       []string `json:"cities,omitempty"`
+}
+
+// TreeCounty is a county with its municipalities in the location tree
+type TreeCounty struct {
+	County         string             `json:"county"`
+	Municipalities []TreeMunicipality `json:"municipalities,omitempty"`
+}
+
+// TreeResponse represents the nested location tree of a province
+type TreeResponse struct {
+	Province string       `json:"province"`
+	Depth    int          `json:"depth"`
+	Counties []TreeCounty `json:"counties"`
+}
+
+// GetLocationTree returns counties, municipalities and cities of a province nested up to the given depth.
+// All levels come from a single query and are grouped in Go to avoid per-level round trips.
+func GetLocationTree(ctx context.Context, province string, depth int) (*TreeResponse, error) {
+	db := database.GetDB()
+	query := `SELECT DISTINCT county, municipality, city_clean FROM postal_codes
+		WHERE province = ? COLLATE NOCASE AND county IS NOT NULL AND municipality IS NOT NULL AND city_clean IS NOT NULL
+		ORDER BY county, municipality, city_clean`
+	defer logSlowQuery(time.Now(), query, []interface{}{province})
+	rows, err := db.QueryContext(ctx, query, province)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	response := &TreeResponse{Province: province, Depth: depth, Counties: []TreeCounty{}}
+	for rows.Next() {
+		var county, municipality, city string
+		if err := rows.Scan(&county, &municipality, &city); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// Rows are ordered, so a new group starts whenever the value changes
+		if n := len(response.Counties); n == 0 || response.Counties[n-1].County != county {
+			response.Counties = append(response.Counties, TreeCounty{County: county})
+		}
+		if depth < TreeDepthMunicipalities {
+			continue
+		}
+
+		currentCounty := &response.Counties[len(response.Counties)-1]
+		if n := len(currentCounty.Municipalities); n == 0 || currentCounty.Municipalities[n-1].Municipality != municipality {
+			currentCounty.Municipalities = append(currentCounty.Municipalities, TreeMunicipality{Municipality: municipality})
+		}
+		if depth < TreeDepthCities {
+			continue
+		}
+
+		currentMunicipality := &currentCounty.Municipalities[len(currentCounty.Municipalities)-1]
+		currentMunicipality.Cities = append(currentMunicipality.Cities, city)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return response, nil
+}
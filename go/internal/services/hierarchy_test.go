@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"postal-api/internal/database"
+)
+
+func TestBuildHierarchyGroupsRecordsAndHandlesNulls(t *testing.T) {
+	records := []database.PostalCode{
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("1-5(n)"), Municipality: strPtr("Warszawa"), County: strPtr("Warszawa"), Province: "mazowieckie"},
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Nowogrodzka"), Municipality: strPtr("Warszawa"), County: strPtr("Warszawa"), Province: "mazowieckie"},
+		{PostalCode: "00-624", City: "Wieś", Province: "mazowieckie"},
+	}
+
+	hierarchy := BuildHierarchy(records)
+	if len(hierarchy) != 1 {
+		t.Fatalf("expected 1 province, got %d", len(hierarchy))
+	}
+
+	counties := hierarchy[0].Counties
+	if len(counties) != 2 {
+		t.Fatalf("expected 2 counties (Warszawa and unknown), got %d", len(counties))
+	}
+
+	cities := counties[0].Municipalities[0].Cities
+	if len(cities) != 1 || len(cities[0].Streets) != 2 {
+		t.Fatalf("expected Warszawa with 2 streets, got %+v", cities)
+	}
+
+	unknown := counties[1]
+	if unknown.County != nil || unknown.Municipalities[0].Municipality != nil {
+		t.Fatalf("expected null county and municipality, got %+v", unknown)
+	}
+	if streets := unknown.Municipalities[0].Cities[0].Streets; len(streets) != 0 {
+		t.Fatalf("expected no streets for a record without street, got %+v", streets)
+	}
+}
+
+func TestGetLocationTreeRespectsDepth(t *testing.T) {
+	openTestDatabase(t)
+	counties, err := GetLocationTree(context.Background(), "opolskie", TreeDepthCounties)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(counties.Counties) == 0 || counties.Counties[0].Municipalities != nil {
+		t.Fatalf("expected counties without municipalities, got %+v", counties.Counties)
+	}
+
+	full, err := GetLocationTree(context.Background(), "opolskie", TreeDepthCities)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(full.Counties) != len(counties.Counties) {
+		t.Fatalf("expected %d counties at full depth, got %d", len(counties.Counties), len(full.Counties))
+	}
+	if cities := full.Counties[0].Municipalities[0].Cities; len(cities) == 0 {
+		t.Fatalf("expected cities at full depth, got %+v", full.Counties[0])
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"strings"
+
+	"postal-api/internal/database"
+	"postal-api/internal/utils"
+)
+
+// Match quality tiers, from strongest to weakest; see database.PostalCode.MatchQuality
+const (
+	MatchQualityExact      = "exact"
+	MatchQualityPrefix     = "prefix"
+	MatchQualityNormalized = "normalized"
+	MatchQualityPartial    = "partial"
+)
+
+// matchQualityRank orders the tiers so the weakest searched name decides a row's quality
+var matchQualityRank = map[string]int{
+	MatchQualityExact:      3,
+	MatchQualityPrefix:     2,
+	MatchQualityNormalized: 1,
+	MatchQualityPartial:    0,
+}
+
+// cityBaseName drops the district suffix of city names like "Kraków (Kraków-Śródmieście)"
+func cityBaseName(city string) string {
+	if i := strings.Index(city, " ("); i >= 0 {
+		return city[:i]
+	}
+	return city
+}
+
+// nameMatchQuality rates how a stored name matches a searched one
+func nameMatchQuality(query, value string) string {
+	query, value = strings.ToLower(query), strings.ToLower(value)
+	if value == query {
+		return MatchQualityExact
+	}
+	if strings.HasPrefix(value, query) {
+		return MatchQualityPrefix
+	}
+
+	normalizedQuery, normalizedValue := utils.NormalizePolishText(query), utils.NormalizePolishText(value)
+	if strings.HasPrefix(normalizedValue, normalizedQuery) {
+		return MatchQualityNormalized
+	}
+	return MatchQualityPartial
+}
+
+// matchQuality rates a row against the searched city and street; administrative-only searches
+// use equality filters, so their rows are exact matches
+func matchQuality(record database.PostalCode, city, street string) string {
+	quality := MatchQualityExact
+	if city != "" {
+		quality = weakerMatchQuality(quality, nameMatchQuality(city, cityBaseName(record.City)))
+	}
+	if street != "" {
+		recordStreet := ""
+		if record.Street != nil {
+			recordStreet = utils.StripStreetPrefix(*record.Street)
+		}
+		quality = weakerMatchQuality(quality, nameMatchQuality(utils.StripStreetPrefix(street), recordStreet))
+	}
+	return quality
+}
+
+// weakerMatchQuality returns the lower of two tiers
+func weakerMatchQuality(a, b string) string {
+	if matchQualityRank[b] < matchQualityRank[a] {
+		return b
+	}
+	return a
+}
+
+// annotateMatchQuality sets MatchQuality on every result in place
+func annotateMatchQuality(results []database.PostalCode, city, street *string) {
+	cityName, streetName := searchedNames(city, street)
+	for i := range results {
+		results[i].MatchQuality = matchQuality(results[i], cityName, streetName)
+	}
+}
+
+// searchedNames returns the trimmed city and street of a search, empty when not searched
+func searchedNames(city, street *string) (string, string) {
+	cityName, streetName := "", ""
+	if city != nil {
+		cityName = strings.TrimSpace(*city)
+	}
+	if street != nil {
+		streetName = strings.TrimSpace(*street)
+	}
+	return cityName, streetName
+}
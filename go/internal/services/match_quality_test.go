@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"postal-api/internal/database"
+	"postal-api/internal/utils"
+)
+
+func TestMatchQualityTiers(t *testing.T) {
+	record := database.PostalCode{City: "Kraków (Kraków-Śródmieście)", Street: strPtr("ul. Długa")}
+
+	tests := []struct {
+		city, street string
+		want         string
+	}{
+		{"kraków", "Długa", MatchQualityExact},
+		{"Kraków", "ul. Długa", MatchQualityExact},
+		{"Krak", "Długa", MatchQualityPrefix},
+		{"Krakow", "Długa", MatchQualityNormalized},
+		{"Kraków", "luga", MatchQualityPartial},
+		{"", "", MatchQualityExact},
+	}
+	for _, tt := range tests {
+		if got := matchQuality(record, tt.city, tt.street); got != tt.want {
+			t.Errorf("matchQuality(%q, %q) = %q, want %q", tt.city, tt.street, got, tt.want)
+		}
+	}
+}
+
+func TestSearchPostalCodesSetsMatchQuality(t *testing.T) {
+	openTestDatabase(t)
+	response, err := SearchPostalCodes(context.Background(), utils.SearchParams{City: strPtr("Kraków"), Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	for _, result := range response.Results {
+		if result.MatchQuality == "" {
+			t.Errorf("result %s has no match_quality", result.PostalCode)
+		}
+	}
+}
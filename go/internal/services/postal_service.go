@@ -1,10 +1,21 @@
 package services
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"postal-api/internal/cache"
+	"postal-api/internal/config"
 	"postal-api/internal/database"
+	"postal-api/internal/i18n"
 	"postal-api/internal/utils"
 )
 
@@ -15,7 +26,99 @@ type SearchResponse struct {
 	SearchType                string                `json:"search_type"`
 	Message                   string                `json:"message,omitempty"`
 	FallbackUsed              bool                  `json:"fallback_used,omitempty"`
+	FallbackLevel             int                   `json:"fallback_level"`
 	PolishNormalizationUsed   bool                  `json:"polish_normalization_used,omitempty"`
+	Exact                     bool                  `json:"exact,omitempty"`
+	LimitClamped              bool                  `json:"limit_clamped,omitempty"`
+	Suggestions               []string              `json:"suggestions,omitempty"`
+	FilteredByProvince        []string              `json:"filtered_by_province,omitempty"`
+	FilteredByCounty          []string              `json:"filtered_by_county,omitempty"`
+	NormalizedParams          *NormalizedParams     `json:"normalized_params,omitempty"`
+	Meta                      *MetaResponse         `json:"meta,omitempty"`
+	Debug                     *DebugInfo            `json:"debug,omitempty"`
+
+	// fields restricts the keys serialized for each result when set
+	fields []string
+	// merged replaces the results when duplicates were collapsed
+	merged []MergedPostalCode
+	// groups replaces the results when they were grouped by postal code
+	groups []PostalCodeGroup
+}
+
+// NormalizedParams echoes the Polish-normalized location filters the normalization tiers searched with
+type NormalizedParams struct {
+	City         *string `json:"city,omitempty"`
+	Street       *string `json:"street,omitempty"`
+	HouseNumber  *string `json:"house_number,omitempty"`
+	Province     *string `json:"province,omitempty"`
+	County       *string `json:"county,omitempty"`
+	Municipality *string `json:"municipality,omitempty"`
+}
+
+// newNormalizedParams copies the location filters of normalized search parameters
+func newNormalizedParams(params utils.SearchParams) *NormalizedParams {
+	return &NormalizedParams{
+		City:         params.City,
+		Street:       params.Street,
+		HouseNumber:  params.HouseNumber,
+		Province:     params.Province,
+		County:       params.County,
+		Municipality: params.Municipality,
+	}
+}
+
+// Fallback levels report how far a search had to degrade to find results, from an exact match upwards.
+// The Polish levels are the house number and street fallbacks repeated on normalized columns.
+const (
+	FallbackLevelExact = iota
+	FallbackLevelPolish
+	FallbackLevelHouseNumber
+	FallbackLevelStreet
+	FallbackLevelPolishHouseNumber
+	FallbackLevelPolishStreet
+	FallbackLevelFuzzy
+	FallbackLevelPhonetic
+)
+
+// SetFields restricts each serialized result to the given PostalCode JSON keys
+func (r *SearchResponse) SetFields(fields []string) {
+	r.fields = fields
+}
+
+// MarshalJSON serializes the response, applying field selection to the results when requested
+func (r SearchResponse) MarshalJSON() ([]byte, error) {
+	type plainResponse SearchResponse
+	if r.groups != nil {
+		return json.Marshal(struct {
+			plainResponse
+			Results []PostalCodeGroup `json:"results"`
+		}{plainResponse(r), r.groups})
+	}
+	if r.merged != nil && len(r.fields) == 0 {
+		return json.Marshal(struct {
+			plainResponse
+			Results []MergedPostalCode `json:"results"`
+		}{plainResponse(r), r.merged})
+	}
+	if len(r.fields) == 0 {
+		return json.Marshal(plainResponse(r))
+	}
+
+	selected := make([]map[string]interface{}, 0, len(r.Results))
+	if r.merged != nil {
+		for _, result := range r.merged {
+			selected = append(selected, result.selectFields(r.fields))
+		}
+	} else {
+		for _, result := range r.Results {
+			selected = append(selected, result.SelectFields(r.fields))
+		}
+	}
+
+	return json.Marshal(struct {
+		plainResponse
+		Results []map[string]interface{} `json:"results"`
+	}{plainResponse(r), selected})
 }
 
 // LocationResponse represents the response structure for location operations
@@ -40,43 +143,328 @@ type ProvinceResponse struct {
 type CountyResponse struct {
 	Counties           []string `json:"counties"`
 	Count              int      `json:"count"`
+	Total              int      `json:"total"`
+	Limit              int      `json:"limit"`
+	Offset             int      `json:"offset"`
 	FilteredByProvince *string  `json:"filtered_by_province,omitempty"`
 	FilteredByPrefix   *string  `json:"filtered_by_prefix,omitempty"`
+	Message            string   `json:"message,omitempty"`
 }
 
 // MunicipalityResponse represents the response for municipalities
 type MunicipalityResponse struct {
 	Municipalities     []string `json:"municipalities"`
 	Count              int      `json:"count"`
+	Total              int      `json:"total"`
+	Limit              int      `json:"limit"`
+	Offset             int      `json:"offset"`
 	FilteredByProvince *string  `json:"filtered_by_province,omitempty"`
 	FilteredByCounty   *string  `json:"filtered_by_county,omitempty"`
 	FilteredByPrefix   *string  `json:"filtered_by_prefix,omitempty"`
+	Message            string   `json:"message,omitempty"`
 }
 
 // CityResponse represents the response for cities
 type CityResponse struct {
 	Cities             []string `json:"cities"`
 	Count              int      `json:"count"`
+	Total              int      `json:"total"`
+	Limit              int      `json:"limit"`
+	Offset             int      `json:"offset"`
 	FilteredByProvince *string  `json:"filtered_by_province,omitempty"`
 	FilteredByCounty   *string  `json:"filtered_by_county,omitempty"`
 	FilteredByMunicipality *string `json:"filtered_by_municipality,omitempty"`
 	FilteredByPrefix   *string  `json:"filtered_by_prefix,omitempty"`
+	Message            string   `json:"message,omitempty"`
+
+	// withPopulation replaces the plain city names when populations were requested
+	withPopulation []CityPopulation
+}
+
+// CityPopulation is a city name with its recorded population
+type CityPopulation struct {
+	City       string `json:"city"`
+	Population *int   `json:"population,omitempty"`
+}
+
+// MarshalJSON serializes the response, emitting city objects with populations when they were requested
+func (r CityResponse) MarshalJSON() ([]byte, error) {
+	type plainResponse CityResponse
+	if r.withPopulation == nil {
+		return json.Marshal(plainResponse(r))
+	}
+
+	return json.Marshal(struct {
+		plainResponse
+		Cities []CityPopulation `json:"cities"`
+	}{plainResponse(r), r.withPopulation})
 }
 
 // StreetResponse represents the response for streets
 type StreetResponse struct {
 	Streets            []string `json:"streets"`
 	Count              int      `json:"count"`
+	Total              int      `json:"total"`
+	Limit              int      `json:"limit"`
+	Offset             int      `json:"offset"`
 	FilteredByCity     *string  `json:"filtered_by_city,omitempty"`
 	FilteredByProvince *string  `json:"filtered_by_province,omitempty"`
 	FilteredByCounty   *string  `json:"filtered_by_county,omitempty"`
 	FilteredByMunicipality *string `json:"filtered_by_municipality,omitempty"`
 	FilteredByPrefix   *string  `json:"filtered_by_prefix,omitempty"`
+	FilteredByPostalCode *string `json:"filtered_by_postal_code,omitempty"`
+
+	// withPostalCodes replaces the plain street names when postal codes were requested
+	withPostalCodes []StreetPostalCodes
+}
+
+// MarshalJSON serializes the response, emitting street objects with postal codes when they were requested
+func (r StreetResponse) MarshalJSON() ([]byte, error) {
+	type plainResponse StreetResponse
+	if r.withPostalCodes == nil {
+		return json.Marshal(plainResponse(r))
+	}
+
+	return json.Marshal(struct {
+		plainResponse
+		Streets []StreetPostalCodes `json:"streets"`
+	}{plainResponse(r), r.withPostalCodes})
+}
+
+// StreetPostalCodes is a street with the distinct postal codes it spans
+type StreetPostalCodes struct {
+	Street      string   `json:"street"`
+	PostalCodes []string `json:"postal_codes"`
+	// HasHouseNumbers is set when any record of the street lists house number ranges
+	HasHouseNumbers bool `json:"has_house_numbers"`
+}
+
+// StreetHouseNumbering reports whether a street's records carry house number ranges
+type StreetHouseNumbering struct {
+	City            string `json:"city"`
+	Street          string `json:"street"`
+	HasHouseNumbers bool   `json:"has_house_numbers"`
+	Records         int    `json:"records"`
+	NumberedRecords int    `json:"numbered_records"`
+}
+
+// hasHouseNumbersColumn selects 1 for records listing house numbers, for use inside an aggregate
+const hasHouseNumbersColumn = "CASE WHEN house_numbers IS NOT NULL AND house_numbers != '' THEN 1 ELSE 0 END"
+
+// DetailedStreetResponse represents the response for streets with their postal codes
+type DetailedStreetResponse struct {
+	Streets                []StreetPostalCodes `json:"streets"`
+	Count                  int                 `json:"count"`
+	Total                  int                 `json:"total"`
+	Limit                  int                 `json:"limit"`
+	Offset                 int                 `json:"offset"`
+	FilteredByCity         *string             `json:"filtered_by_city,omitempty"`
+	FilteredByProvince     *string             `json:"filtered_by_province,omitempty"`
+	FilteredByCounty       *string             `json:"filtered_by_county,omitempty"`
+	FilteredByMunicipality *string             `json:"filtered_by_municipality,omitempty"`
+	FilteredByPrefix       *string             `json:"filtered_by_prefix,omitempty"`
+	FilteredByPostalCode   *string             `json:"filtered_by_postal_code,omitempty"`
+}
+
+// searchSortColumns whitelists the sort keys accepted by the search endpoint and maps them to columns
+var searchSortColumns = map[string]string{
+	"postal_code": "postal_code",
+	"city":        "city_clean",
+	"street":      "street",
+}
+
+// defaultSearchSort is used when no sort key is supplied
+const defaultSearchSort = "postal_code"
+
+// ValidateSortParam checks that a sort value has the form "key" or "key:asc|desc" with a whitelisted key
+func ValidateSortParam(sort string) error {
+	if sort == "" {
+		return nil
+	}
+
+	key, direction, hasDirection := strings.Cut(sort, ":")
+	if _, ok := searchSortColumns[key]; !ok {
+		return fmt.Errorf("invalid sort key '%s'", key)
+	}
+	if hasDirection && direction != "asc" && direction != "desc" {
+		return fmt.Errorf("invalid sort direction '%s'", direction)
+	}
+	return nil
+}
+
+// buildOrderByClause translates a validated sort value into an ORDER BY clause
+func buildOrderByClause(sort string) string {
+	if sort == "" {
+		sort = defaultSearchSort
+	}
+
+	key, direction, _ := strings.Cut(sort, ":")
+	column, ok := searchSortColumns[key]
+	if !ok {
+		column = searchSortColumns[defaultSearchSort]
+	}
+
+	// id is appended as a final tiebreaker so identical requests return rows in the same order
+	if direction == "desc" {
+		return fmt.Sprintf(" ORDER BY %s DESC, id", column)
+	}
+	return fmt.Sprintf(" ORDER BY %s ASC, id", column)
 }
 
 // buildSearchQuery builds a search query with the given parameters
 func buildSearchQuery(params utils.SearchParams, useNormalized bool) (string, []interface{}) {
-	query := "SELECT * FROM postal_codes WHERE 1=1"
+	conditions, args := buildSearchConditions(params, useNormalized)
+	query := "SELECT " + database.PostalCodeColumns() + " FROM postal_codes WHERE 1=1" + conditions
+
+	cityCol := "city_clean"
+	if useNormalized {
+		cityCol = "city_normalized"
+	}
+	orderBy, orderArgs := searchOrderBy(params, cityCol)
+	query += orderBy
+	args = append(args, orderArgs...)
+
+	// A zero limit streams every matching row
+	if params.Limit == 0 {
+		return query, args
+	}
+
+	// Use a larger limit since we'll filter in Go
+	sqlLimit := params.Limit
+	if (params.HouseNumber != nil && *params.HouseNumber != "") || params.Side != "" {
+		sqlLimit = min(params.Limit*5, 1000)
+	}
+	query += " LIMIT ?"
+	args = append(args, sqlLimit)
+
+	return query, args
+}
+
+// maxLooseStreetTokens caps the number of street words turned into LIKE clauses in loose mode
+const maxLooseStreetTokens = 5
+
+// streetTokens splits a street into at most maxLooseStreetTokens words
+func streetTokens(street string) []string {
+	tokens := strings.Fields(street)
+	if len(tokens) > maxLooseStreetTokens {
+		tokens = tokens[:maxLooseStreetTokens]
+	}
+	return tokens
+}
+
+// SplitListFilter splits a comma-separated filter value into its trimmed, non-empty items
+func SplitListFilter(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// appendListFilter adds a case-insensitive equality condition for a single value,
+// or an IN condition when the value is a comma-separated list
+func appendListFilter(query string, args []interface{}, column string, value *string) (string, []interface{}) {
+	return appendListFilterCase(query, args, column, value, false)
+}
+
+// appendListFilterCase is appendListFilter with the case sensitivity chosen by the caller
+func appendListFilterCase(query string, args []interface{}, column string, value *string, caseSensitive bool) (string, []interface{}) {
+	if value == nil || *value == "" {
+		return query, args
+	}
+
+	collate := " COLLATE NOCASE"
+	if caseSensitive {
+		collate = ""
+	}
+
+	items := SplitListFilter(*value)
+	if len(items) == 1 {
+		return query + fmt.Sprintf(" AND %s = ?%s", column, collate), append(args, items[0])
+	}
+
+	placeholders := make([]string, len(items))
+	for i, item := range items {
+		placeholders[i] = "?"
+		args = append(args, item)
+	}
+	return query + fmt.Sprintf(" AND %s%s IN (%s)", column, collate, strings.Join(placeholders, ", ")), args
+}
+
+// How matchCondition compares a column with a search term
+const (
+	matchWhole = iota
+	matchPrefix
+	matchSubstring
+)
+
+// matchCondition builds a search condition comparing column with value as a whole, a prefix or a substring.
+// SQLite's LIKE ignores ASCII case whatever the collation, so case-sensitive patterns use instr instead.
+func matchCondition(column, value string, mode int, caseSensitive bool) (string, interface{}) {
+	switch {
+	case mode == matchWhole && caseSensitive:
+		return fmt.Sprintf(" AND %s = ?", column), value
+	case mode == matchWhole:
+		return fmt.Sprintf(" AND %s = ? COLLATE NOCASE", column), value
+	case mode == matchPrefix && caseSensitive:
+		return fmt.Sprintf(" AND instr(%s, ?) = 1", column), value
+	case mode == matchPrefix:
+		return fmt.Sprintf(" AND %s LIKE ? COLLATE NOCASE", column), value + "%"
+	case caseSensitive:
+		return fmt.Sprintf(" AND instr(%s, ?) > 0", column), value
+	default:
+		return fmt.Sprintf(" AND %s LIKE ? COLLATE NOCASE", column), "%" + value + "%"
+	}
+}
+
+// matchAnyCondition builds a search condition matching column against any of the values, like matchCondition
+func matchAnyCondition(column string, values []string, mode int, caseSensitive bool) (string, []interface{}) {
+	conditions := make([]string, 0, len(values))
+	args := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		condition, arg := matchCondition(column, value, mode, caseSensitive)
+		conditions = append(conditions, strings.TrimPrefix(condition, " AND "))
+		args = append(args, arg)
+	}
+	if len(conditions) == 1 {
+		return " AND " + conditions[0], args
+	}
+	return " AND (" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// streetVariants expands the street abbreviations of a searched street, Polish-normalizing each spelling for
+// the normalized columns. A street-type prefix is expanded like any abbreviation, so "al. Jerozolimskie" also
+// matches "Aleje Jerozolimskie", and the street without its prefix is kept as well for names stored bare.
+func streetVariants(street string, useNormalized bool) []string {
+	variants := utils.ExpandStreetAbbreviations(street)
+	if stripped := utils.StripStreetPrefix(street); stripped != street {
+		for _, variant := range utils.ExpandStreetAbbreviations(stripped) {
+			if !slices.Contains(variants, variant) {
+				variants = append(variants, variant)
+			}
+		}
+	}
+	if len(variants) == 0 {
+		return []string{street}
+	}
+	if !useNormalized {
+		return variants
+	}
+
+	normalized := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		if variant = utils.NormalizePolishText(variant); !slices.Contains(normalized, variant) {
+			normalized = append(normalized, variant)
+		}
+	}
+	return normalized
+}
+
+// buildSearchConditions builds the WHERE conditions shared by the search and count queries
+func buildSearchConditions(params utils.SearchParams, useNormalized bool) (string, []interface{}) {
+	query := ""
 	var args []interface{}
 
 	// Choose column names based on whether we're using normalized search
@@ -88,38 +476,42 @@ func buildSearchQuery(params utils.SearchParams, useNormalized bool) (string, []
 		streetCol = "street_normalized"
 	}
 
-	if params.City != nil && *params.City != "" {
-		query += fmt.Sprintf(" AND %s LIKE ? COLLATE NOCASE", cityCol)
-		args = append(args, *params.City+"%")
+	// Exact mode compares whole values instead of prefix/substring patterns.
+	// In the normalized tier this still matches across Polish characters,
+	// e.g. "Lodz" exactly matches "Łódź" via city_normalized.
+	cityMode, streetMode := matchPrefix, matchSubstring
+	if params.Exact {
+		cityMode, streetMode = matchWhole, matchWhole
 	}
 
-	if params.Street != nil && *params.Street != "" {
-		query += fmt.Sprintf(" AND %s LIKE ? COLLATE NOCASE", streetCol)
-		args = append(args, "%"+*params.Street+"%")
+	if params.City != nil && *params.City != "" {
+		condition, arg := matchCondition(cityCol, *params.City, cityMode, params.CaseSensitive)
+		query += condition
+		args = append(args, arg)
 	}
 
-	if params.Province != nil && *params.Province != "" {
-		query += " AND province = ? COLLATE NOCASE"
-		args = append(args, *params.Province)
+	if params.Street != nil && *params.Street != "" && params.Loose {
+		// Loose mode: every word must appear somewhere in the street name, in any order.
+		// In the normalized tier the words come from the normalized street, so each is Polish-normalized.
+		for _, token := range streetTokens(utils.StripStreetPrefix(*params.Street)) {
+			condition, tokenArgs := matchAnyCondition(streetCol, streetVariants(token, useNormalized), matchSubstring, params.CaseSensitive)
+			query += condition
+			args = append(args, tokenArgs...)
+		}
+	} else if params.Street != nil && *params.Street != "" {
+		condition, streetArgs := matchAnyCondition(streetCol, streetVariants(*params.Street, useNormalized), streetMode, params.CaseSensitive)
+		query += condition
+		args = append(args, streetArgs...)
 	}
 
-	if params.County != nil && *params.County != "" {
-		query += " AND county = ? COLLATE NOCASE"
-		args = append(args, *params.County)
-	}
+	query, args = appendListFilterCase(query, args, "province", params.Province, params.CaseSensitive)
+	query, args = appendListFilterCase(query, args, "county", params.County, params.CaseSensitive)
 
 	if params.Municipality != nil && *params.Municipality != "" {
-		query += " AND municipality = ? COLLATE NOCASE"
-		args = append(args, *params.Municipality)
-	}
-
-	// Use a larger limit since we'll filter in Go
-	sqlLimit := params.Limit
-	if params.HouseNumber != nil && *params.HouseNumber != "" {
-		sqlLimit = min(params.Limit*5, 1000)
+		condition, arg := matchCondition("municipality", *params.Municipality, matchWhole, params.CaseSensitive)
+		query += condition
+		args = append(args, arg)
 	}
-	query += " LIMIT ?"
-	args = append(args, sqlLimit)
 
 	return query, args
 }
@@ -132,8 +524,40 @@ func min(a, b int) int {
 	return b
 }
 
-// filterByHouseNumber filters database results by house number using the range matching logic
-func filterByHouseNumber(results []database.PostalCode, houseNumber *string, limit int) []database.PostalCode {
+// filterBySide keeps records consistent with the requested odd/even side.
+// With a house number the searched number itself must have the requested parity;
+// otherwise records are kept when their range can contain numbers on that side.
+// Records without house_numbers cover the whole street and are always kept.
+func filterBySide(results []database.PostalCode, side string, houseNumber *string) []database.PostalCode {
+	if side == "" {
+		return results
+	}
+
+	if houseNumber != nil && *houseNumber != "" {
+		if utils.IsHouseNumberOnSide(*houseNumber, side) {
+			return results
+		}
+		return nil
+	}
+
+	var filteredResults []database.PostalCode
+	for _, row := range results {
+		if coversSide(row, side) {
+			filteredResults = append(filteredResults, row)
+		}
+	}
+	return filteredResults
+}
+
+// coversSide reports whether a record's range can contain house numbers on the side; records without
+// house_numbers cover the whole street
+func coversSide(row database.PostalCode, side string) bool {
+	return row.HouseNumbers == nil || *row.HouseNumbers == "" || utils.RangeIncludesSide(*row.HouseNumbers, side)
+}
+
+// filterByHouseNumber filters database results by house number using the range matching logic.
+// Records without house_numbers are dropped unless assumeAllWhenEmpty treats them as the whole street.
+func filterByHouseNumber(results []database.PostalCode, houseNumber *string, limit int, assumeAllWhenEmpty bool) []database.PostalCode {
 	if houseNumber == nil || *houseNumber == "" {
 		if len(results) > limit {
 			return results[:limit]
@@ -144,526 +568,1382 @@ func filterByHouseNumber(results []database.PostalCode, houseNumber *string, lim
 	var filteredResults []database.PostalCode
 
 	for _, row := range results {
-		// Records without house_numbers don't match specific house number searches
-		if row.HouseNumbers == nil || *row.HouseNumbers == "" {
+		if !matchHouseNumber(&row, *houseNumber, assumeAllWhenEmpty) {
 			continue
 		}
+		filteredResults = append(filteredResults, row)
 
-		// Use the range matching logic
-		if utils.IsHouseNumberInRange(*houseNumber, *row.HouseNumbers) {
-			filteredResults = append(filteredResults, row)
-
-			// Stop when we have enough results
-			if len(filteredResults) >= limit {
-				break
-			}
+		// Stop when we have enough results
+		if len(filteredResults) >= limit {
+			break
 		}
 	}
 
 	return filteredResults
 }
 
-// executeFallbackSearch executes fallback search logic when initial search returned no results
-func executeFallbackSearch(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, bool, string, error) {
-	db := database.GetDB()
+// matchHouseNumber reports whether a record covers the house number and records the range that matched.
+// Records without house_numbers don't match specific house number searches unless assumeAllWhenEmpty is set.
+func matchHouseNumber(row *database.PostalCode, houseNumber string, assumeAllWhenEmpty bool) bool {
+	if row.HouseNumbers == nil || *row.HouseNumbers == "" {
+		return assumeAllWhenEmpty
+	}
+
+	matchedRange, ok := utils.MatchingRange(houseNumber, *row.HouseNumbers)
+	if ok {
+		row.MatchedRange = &matchedRange
+	}
+	return ok
+}
 
-	fallbackUsed := false
+// executeFallbackSearch executes fallback search logic when initial search returned no results. It returns
+// FallbackLevelHouseNumber or FallbackLevelStreet for the fallback that found results, or FallbackLevelExact when none did.
+func executeFallbackSearch(ctx context.Context, params utils.SearchParams, useNormalized bool) ([]database.PostalCode, int, string, error) {
+	fallbackLevel := FallbackLevelExact
 	fallbackMessage := ""
 	var results []database.PostalCode
 
+	tier := "fallback"
+	if useNormalized {
+		tier = "polish_fallback"
+	}
+
 	// Fallback 1: Remove house_number if present
 	if params.HouseNumber != nil && *params.HouseNumber != "" {
 		// Re-run query without house_number considerations
 		fallbackParams := params
 		fallbackParams.HouseNumber = nil
-		query, args := buildSearchQuery(fallbackParams, useNormalized)
-		rows, err := db.Query(query, args...)
+		var err error
+		results, err = repository.Search(ctx, tier, fallbackParams, useNormalized)
 		if err != nil {
-			return nil, false, "", fmt.Errorf("fallback database query failed: %w", err)
-		}
-		defer rows.Close()
-
-		results = nil
-		for rows.Next() {
-			var pc database.PostalCode
-			var id int
-			var cityNormalized, streetNormalized, cityClean interface{}
-			var population interface{}
-			err := rows.Scan(&id, &pc.PostalCode, &pc.City, &pc.Street, &pc.HouseNumbers, &pc.Municipality, &pc.County, &pc.Province, &cityNormalized, &streetNormalized, &cityClean, &population)
-			if err != nil {
-				return nil, false, "", fmt.Errorf("failed to scan fallback row: %w", err)
-			}
-			results = append(results, pc)
+			return nil, FallbackLevelExact, "", fmt.Errorf("fallback search failed: %w", err)
 		}
 
 		if len(results) > 0 {
-			fallbackUsed = true
+			fallbackLevel = FallbackLevelHouseNumber
 			var locationDesc []string
 			if params.Street != nil && *params.Street != "" {
-				locationDesc = append(locationDesc, fmt.Sprintf("street '%s'", *params.Street))
+				locationDesc = append(locationDesc, i18n.TranslateContext(ctx, i18n.MsgLocationStreet, *params.Street))
 			}
 			if params.City != nil && *params.City != "" {
-				locationDesc = append(locationDesc, fmt.Sprintf("city '%s'", *params.City))
+				locationDesc = append(locationDesc, i18n.TranslateContext(ctx, i18n.MsgLocationCity, *params.City))
 			}
 			locationStr := ""
 			if len(locationDesc) > 0 {
-				locationStr = " in " + strings.Join(locationDesc, " in ")
+				separator := i18n.TranslateContext(ctx, i18n.MsgLocationSeparator)
+				locationStr = i18n.TranslateContext(ctx, i18n.MsgLocationSuffix, strings.Join(locationDesc, separator))
 			}
-			fallbackMessage = fmt.Sprintf("House number '%s' not found%s. Showing all results%s.", *params.HouseNumber, locationStr, locationStr)
+			fallbackMessage = i18n.TranslateContext(ctx, i18n.MsgHouseNumberNotFound, *params.HouseNumber, locationStr)
 		}
 	}
 
-	// Fallback 2: Remove street if still no results and we have city + street
+	// Fallback 2: Remove street if still no results and we have city + street.
+	// Street-only searches skip this tier since dropping the street would leave no location filter.
 	if len(results) == 0 && params.City != nil && *params.City != "" && params.Street != nil && *params.Street != "" {
 		fallbackParams := params
 		fallbackParams.Street = nil
 		fallbackParams.HouseNumber = nil
-		query, args := buildSearchQuery(fallbackParams, useNormalized)
-		rows, err := db.Query(query, args...)
+		var err error
+		results, err = repository.Search(ctx, tier, fallbackParams, useNormalized)
 		if err != nil {
-			return nil, false, "", fmt.Errorf("second fallback database query failed: %w", err)
-		}
-		defer rows.Close()
-
-		results = nil
-		for rows.Next() {
-			var pc database.PostalCode
-			var id int
-			var cityNormalized, streetNormalized, cityClean interface{}
-			var population interface{}
-			err := rows.Scan(&id, &pc.PostalCode, &pc.City, &pc.Street, &pc.HouseNumbers, &pc.Municipality, &pc.County, &pc.Province, &cityNormalized, &streetNormalized, &cityClean, &population)
-			if err != nil {
-				return nil, false, "", fmt.Errorf("failed to scan second fallback row: %w", err)
-			}
-			results = append(results, pc)
+			return nil, FallbackLevelExact, "", fmt.Errorf("second fallback search failed: %w", err)
 		}
 
 		if len(results) > 0 {
-			fallbackUsed = true
+			fallbackLevel = FallbackLevelStreet
 			if params.HouseNumber != nil && *params.HouseNumber != "" {
-				fallbackMessage = fmt.Sprintf("Street '%s' with house number '%s' not found in %s. Showing all results for %s.", *params.Street, *params.HouseNumber, *params.City, *params.City)
+				fallbackMessage = i18n.TranslateContext(ctx, i18n.MsgStreetWithHouseNumberNotFound, *params.Street, *params.HouseNumber, *params.City)
 			} else {
-				fallbackMessage = fmt.Sprintf("Street '%s' not found in %s. Showing all results for %s.", *params.Street, *params.City, *params.City)
+				fallbackMessage = i18n.TranslateContext(ctx, i18n.MsgStreetNotFound, *params.Street, *params.City)
 			}
 		}
 	}
 
-	return results, fallbackUsed, fallbackMessage, nil
-}
+	// Fallbacks drop the house number, so the side filter is applied to the ranges only
+	results = filterBySide(results, params.Side, nil)
+	if len(results) > params.Limit {
+		results = results[:params.Limit]
+	}
 
-// SearchPostalCodes searches postal codes with four-tier approach: exact, Polish normalization, fallbacks, then Polish fallbacks
-func SearchPostalCodes(params utils.SearchParams) (*SearchResponse, error) {
-	// Pre-calculate normalized parameters once
-	normalizedParams := utils.GetNormalizedSearchParams(params)
+	return results, fallbackLevel, fallbackMessage, nil
+}
 
-	polishFallbackUsed := false
-	searchType := "exact"
-	fallbackUsed := false
-	fallbackMessage := ""
+// isAdministrativeOnlySearch reports whether the search has no city or street and relies on administrative filters only
+func isAdministrativeOnlySearch(params utils.SearchParams) bool {
+	hasCity := params.City != nil && *params.City != ""
+	hasStreet := params.Street != nil && *params.Street != ""
+	return !hasCity && !hasStreet
+}
 
-	// Tier 1: Exact search with original parameters
-	db := database.GetDB()
-	query, args := buildSearchQuery(params, false)
-	rows, err := db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("database query failed: %w", err)
+// describeAdministrativeFilters builds a human readable description of the administrative filters in the request's language
+func describeAdministrativeFilters(ctx context.Context, params utils.SearchParams) string {
+	var parts []string
+	if params.Municipality != nil && *params.Municipality != "" {
+		parts = append(parts, i18n.TranslateContext(ctx, i18n.MsgFilterMunicipality, *params.Municipality))
 	}
-	defer rows.Close()
+	if params.County != nil && *params.County != "" {
+		parts = append(parts, i18n.TranslateContext(ctx, i18n.MsgFilterCounty, *params.County))
+	}
+	if params.Province != nil && *params.Province != "" {
+		parts = append(parts, i18n.TranslateContext(ctx, i18n.MsgFilterProvince, *params.Province))
+	}
+	return strings.Join(parts, i18n.TranslateContext(ctx, i18n.MsgLocationSeparator))
+}
 
-	var sqlResults []database.PostalCode
-	for rows.Next() {
-		var pc database.PostalCode
-		var id int
-		var cityNormalized, streetNormalized, cityClean interface{}
-		var population interface{}
-		err := rows.Scan(&id, &pc.PostalCode, &pc.City, &pc.Street, &pc.HouseNumbers, &pc.Municipality, &pc.County, &pc.Province, &cityNormalized, &streetNormalized, &cityClean, &population)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+// explainEmptyHierarchy explains an empty result caused by a province or county that does not exist,
+// or by a county that lies in a different province. It returns an empty string when the combination is valid.
+func explainEmptyHierarchy(ctx context.Context, province, county *string) (string, error) {
+	var provinces []string
+	if province != nil && *province != "" {
+		provinces = SplitListFilter(*province)
+		for _, p := range provinces {
+			exists, err := repository.ProvinceExists(ctx, p)
+			if err != nil {
+				return "", err
+			}
+			if !exists {
+				return i18n.TranslateContext(ctx, i18n.MsgProvinceNotFound, p), nil
+			}
 		}
-		sqlResults = append(sqlResults, pc)
 	}
 
-	exactResults := filterByHouseNumber(sqlResults, params.HouseNumber, params.Limit)
-	var results []database.PostalCode
+	if county == nil || *county == "" {
+		return "", nil
+	}
 
-	if len(exactResults) > 0 {
-		results = exactResults
-	} else {
-		// Tier 2: Polish character normalization search
-		query, args := buildSearchQuery(normalizedParams, true)
-		rows, err := db.Query(query, args...)
+	for _, c := range SplitListFilter(*county) {
+		countyProvinces, err := repository.ProvincesOfCounty(ctx, c)
 		if err != nil {
-			return nil, fmt.Errorf("normalized database query failed: %w", err)
+			return "", err
 		}
-		defer rows.Close()
 
-		var polishSqlResults []database.PostalCode
-		for rows.Next() {
-			var pc database.PostalCode
-			var id int
-			var cityNormalized, streetNormalized, cityClean interface{}
-			var population interface{}
-			err := rows.Scan(&id, &pc.PostalCode, &pc.City, &pc.Street, &pc.HouseNumbers, &pc.Municipality, &pc.County, &pc.Province, &cityNormalized, &streetNormalized, &cityClean, &population)
-			if err != nil {
-				return nil, fmt.Errorf("failed to scan normalized row: %w", err)
-			}
-			polishSqlResults = append(polishSqlResults, pc)
+		if len(countyProvinces) == 0 {
+			return i18n.TranslateContext(ctx, i18n.MsgCountyNotFound, c), nil
 		}
+		if len(provinces) > 0 && !containsFold(provinces, countyProvinces) {
+			return i18n.TranslateContext(ctx, i18n.MsgCountyOutsideProvince, c, *province, strings.Join(countyProvinces, ", ")), nil
+		}
+	}
 
-		polishResults := filterByHouseNumber(polishSqlResults, normalizedParams.HouseNumber, params.Limit)
-
-		if len(polishResults) > 0 {
-			results = polishResults
-			polishFallbackUsed = true
-			searchType = "polish_characters"
-		} else {
-			// Tier 3: Original fallback logic (house_number → street → city-only)
-			tier3Results, tier3FallbackUsed, tier3FallbackMessage, err := executeFallbackSearch(params, false)
-			if err != nil {
-				return nil, fmt.Errorf("tier 3 fallback failed: %w", err)
-			}
-
-			// Tier 4: Polish normalization fallback logic (only if Tier 3 failed)
-			if len(tier3Results) == 0 {
-				tier4Results, tier4FallbackUsed, tier4FallbackMessage, err := executeFallbackSearch(normalizedParams, true)
-				if err != nil {
-					return nil, fmt.Errorf("tier 4 fallback failed: %w", err)
-				}
+	return "", nil
+}
 
-				if len(tier4Results) > 0 {
-					results = tier4Results
-					fallbackUsed = tier4FallbackUsed
-					fallbackMessage = tier4FallbackMessage
-					polishFallbackUsed = true
-					searchType = "polish_characters"
-				}
-			} else {
-				results = tier3Results
-				fallbackUsed = tier3FallbackUsed
-				fallbackMessage = tier3FallbackMessage
+// containsFold reports whether any candidate equals any of the values, ignoring case
+func containsFold(values, candidates []string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if strings.EqualFold(value, candidate) {
+				return true
 			}
 		}
 	}
+	return false
+}
 
-	response := &SearchResponse{
-		Results:    results,
-		Count:      len(results),
-		SearchType: searchType,
+// emptyLocationMessage explains an empty location list, distinguishing invalid hierarchy filters from missing data
+// reported with the notFoundID message
+func emptyLocationMessage(ctx context.Context, notFoundID string, province, county *string) (string, error) {
+	message, err := explainEmptyHierarchy(ctx, province, county)
+	if err != nil || message != "" {
+		return message, err
 	}
+	return i18n.TranslateContext(ctx, notFoundID), nil
+}
 
-	if fallbackUsed {
-		response.Message = fallbackMessage
-		response.FallbackUsed = true
+// distinctCitiesCache holds the distinct city names used for fuzzy matching
+var distinctCitiesCache = cache.New[[]string](config.LocationCacheTTL())
+
+// getDistinctCities returns all distinct city names ordered by population, cached like the location lists
+func getDistinctCities(ctx context.Context) ([]string, error) {
+	if cached, ok := distinctCitiesCache.Get(""); ok {
+		return cached, nil
 	}
 
-	if polishFallbackUsed {
-		if response.Message != "" {
-			response.Message += " Polish characters were normalized for search."
-		} else {
-			response.Message = "Search performed with Polish character normalization."
-		}
-		response.PolishNormalizationUsed = true
+	// Larger cities come first so they win ties in edit distance
+	cities, err := repository.ListCitiesByPopulation(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	distinctCitiesCache.Set("", cities)
+	return cities, nil
 }
 
-// GetPostalCodeByCode gets postal code records by postal code
-func GetPostalCodeByCode(postalCode string) (*SearchResponse, error) {
-	db := database.GetDB()
-	query := "SELECT * FROM postal_codes WHERE postal_code = ?"
-	rows, err := db.Query(query, postalCode)
+// findClosestCity returns the city name with the smallest edit distance within the length-scaled threshold,
+// preferring the more populous city on ties
+func findClosestCity(ctx context.Context, city string) (string, bool, error) {
+	cities, err := getDistinctCities(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("database query failed: %w", err)
+		return "", false, err
 	}
-	defer rows.Close()
 
-	var results []database.PostalCode
-	for rows.Next() {
-		var pc database.PostalCode
-		var id int
-		var cityNormalized, streetNormalized, cityClean interface{}
-		var population interface{}
-		err := rows.Scan(&id, &pc.PostalCode, &pc.City, &pc.Street, &pc.HouseNumbers, &pc.Municipality, &pc.County, &pc.Province, &cityNormalized, &streetNormalized, &cityClean, &population)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+	input := utils.FuzzyKey(city)
+	threshold := utils.FuzzyThreshold(input)
+	bestCity := ""
+	bestDistance := threshold + 1
+	for _, candidate := range cities {
+		distance := utils.LevenshteinDistance(input, utils.FuzzyKey(candidate))
+		if distance < bestDistance {
+			bestCity = candidate
+			bestDistance = distance
 		}
-		results = append(results, pc)
-	}
-
-	if len(results) == 0 {
-		return nil, nil
 	}
 
-	return &SearchResponse{
-		Results: results,
-		Count:   len(results),
-	}, nil
+	return bestCity, bestCity != "", nil
 }
 
-// GetProvinces gets all provinces, optionally filtered by prefix
-func GetProvinces(prefix *string) (*ProvinceResponse, error) {
-	db := database.GetDB()
-	query := "SELECT DISTINCT province FROM postal_codes WHERE province IS NOT NULL ORDER BY province"
-	rows, err := db.Query(query)
+// maxCitySuggestions caps the "did you mean" suggestions returned on empty results
+const maxCitySuggestions = 5
+
+// suggestCities returns up to maxCitySuggestions city names closest to the input, ordered by ascending edit distance
+func suggestCities(ctx context.Context, city string) ([]string, error) {
+	cities, err := getDistinctCities(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("database query failed: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
 
-	var allProvinces []string
-	for rows.Next() {
-		var province string
-		if err := rows.Scan(&province); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+	type candidate struct {
+		city     string
+		distance int
+	}
+
+	// Suggestions tolerate one more edit than the fuzzy tier since they are only hints
+	input := utils.FuzzyKey(city)
+	threshold := utils.FuzzyThreshold(input) + 1
+	var candidates []candidate
+	for _, name := range cities {
+		if distance := utils.LevenshteinDistance(input, utils.FuzzyKey(name)); distance <= threshold {
+			candidates = append(candidates, candidate{city: name, distance: distance})
 		}
-		allProvinces = append(allProvinces, province)
 	}
 
-	var filteredProvinces []string
-	if prefix != nil && *prefix != "" {
-		normalizedPrefix := strings.ToLower(utils.NormalizePolishText(*prefix))
-		originalPrefix := strings.ToLower(*prefix)
+	// Stable sort keeps the population order for equal distances
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
 
-		for _, province := range allProvinces {
-			provinceLower := strings.ToLower(province)
-			normalizedProvince := strings.ToLower(utils.NormalizePolishText(province))
-			if strings.HasPrefix(provinceLower, originalPrefix) || strings.HasPrefix(normalizedProvince, normalizedPrefix) {
-				filteredProvinces = append(filteredProvinces, province)
-			}
+	var suggestions []string
+	for _, c := range candidates {
+		if len(suggestions) == maxCitySuggestions {
+			break
 		}
+		suggestions = append(suggestions, c.city)
+	}
+	return suggestions, nil
+}
+
+// executeFuzzySearch retries the search with the closest known city name, returning nil when none is close enough
+func executeFuzzySearch(ctx context.Context, params utils.SearchParams) (*SearchResponse, error) {
+	correctedCity, found, err := findClosestCity(ctx, *params.City)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	fuzzyParams := params
+	fuzzyParams.City = &correctedCity
+	fuzzyParams.Fuzzy = false
+	response, err := searchPostalCodes(ctx, fuzzyParams)
+	if err != nil || response.Count == 0 {
+		return nil, err
+	}
+
+	correction := i18n.TranslateContext(ctx, i18n.MsgFuzzyCorrection, *params.City, correctedCity)
+	if response.Message != "" {
+		response.Message = correction + " " + response.Message
 	} else {
-		filteredProvinces = allProvinces
+		response.Message = correction
 	}
+	response.SearchType = "fuzzy"
+	response.FallbackLevel = FallbackLevelFuzzy
+	return response, nil
+}
 
-	return &ProvinceResponse{
-		Provinces:        filteredProvinces,
-		Count:            len(filteredProvinces),
-		FilteredByPrefix: prefix,
+// phoneticCitiesCache maps the phonetic key of every city to the cities sharing it, largest first
+var phoneticCitiesCache = cache.New[map[string][]string](config.LocationCacheTTL())
+
+// getPhoneticCities returns the cities grouped by phonetic key, computed once per cache lifetime
+func getPhoneticCities(ctx context.Context) (map[string][]string, error) {
+	if cached, ok := phoneticCitiesCache.Get(""); ok {
+		return cached, nil
+	}
+
+	cities, err := getDistinctCities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string][]string)
+	for _, city := range cities {
+		key := utils.PhoneticKey(city)
+		byKey[key] = append(byKey[key], city)
+	}
+	phoneticCitiesCache.Set("", byKey)
+	return byKey, nil
+}
+
+// minPhoneticKeyLength keeps very short keys, which many unrelated names share, out of phonetic matching
+const minPhoneticKeyLength = 3
+
+// findPhoneticCity returns the most populous city sounding like the input, other than the input itself
+func findPhoneticCity(ctx context.Context, city string) (string, bool, error) {
+	key := utils.PhoneticKey(city)
+	if len(key) < minPhoneticKeyLength {
+		return "", false, nil
+	}
+
+	byKey, err := getPhoneticCities(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	for _, candidate := range byKey[key] {
+		if !strings.EqualFold(candidate, strings.TrimSpace(city)) {
+			return candidate, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// executePhoneticSearch retries the search with the most populous city that sounds like the input, returning nil
+// when no city does
+func executePhoneticSearch(ctx context.Context, params utils.SearchParams) (*SearchResponse, error) {
+	matchedCity, found, err := findPhoneticCity(ctx, *params.City)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	phoneticParams := params
+	phoneticParams.City = &matchedCity
+	phoneticParams.Fuzzy = false
+	phoneticParams.Phonetic = false
+	response, err := searchPostalCodes(ctx, phoneticParams)
+	if err != nil || response.Count == 0 {
+		return nil, err
+	}
+
+	correction := i18n.TranslateContext(ctx, i18n.MsgPhoneticCorrection, *params.City, matchedCity)
+	if response.Message != "" {
+		response.Message = correction + " " + response.Message
+	} else {
+		response.Message = correction
+	}
+	response.SearchType = "phonetic"
+	response.FallbackLevel = FallbackLevelPhonetic
+	return response, nil
+}
+
+// SearchPostalCodes searches postal codes through the search tiers, then reshapes the results as requested:
+// GroupBy "postal_code" lists the streets of each postal code and Dedupe collapses rows sharing a postal code,
+// street and city; callers reject requests asking for both
+func SearchPostalCodes(ctx context.Context, params utils.SearchParams) (*SearchResponse, error) {
+	response, err := searchPostalCodes(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.GroupBy == GroupByPostalCode {
+		response.groups = groupByPostalCode(response.Results)
+		response.Count = len(response.groups)
+	} else if params.Dedupe {
+		response.merged = dedupeResults(response.Results)
+		response.Count = len(response.merged)
+	}
+	return response, nil
+}
+
+// searchPostalCodes searches postal codes with four-tier approach: exact, Polish normalization, fallbacks, then Polish fallbacks.
+// SkipNormalization drops the Polish tiers and SkipFallback the fallback tiers.
+func searchPostalCodes(ctx context.Context, params utils.SearchParams) (*SearchResponse, error) {
+	// Pre-calculate normalized parameters once
+	normalizedParams := utils.GetNormalizedSearchParams(params)
+
+	polishFallbackUsed := false
+	searchType := "exact"
+	fallbackLevel := FallbackLevelExact
+	fallbackMessage := ""
+
+	// Tier 1: Exact search with original parameters
+	sqlResults, err := repository.Search(ctx, "exact", params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	exactResults := filterByHouseNumber(filterBySide(sqlResults, params.Side, params.HouseNumber), params.HouseNumber, params.Limit, params.AssumeAllWhenEmpty)
+	var results []database.PostalCode
+
+	tier := "exact"
+	if len(exactResults) > 0 {
+		results = exactResults
+	} else {
+		// Tier 2: Polish character normalization search, unless disabled for clean inputs
+		var polishResults []database.PostalCode
+		if !params.SkipNormalization {
+			polishSqlResults, err := repository.Search(ctx, "polish_characters", normalizedParams, true)
+			if err != nil {
+				return nil, fmt.Errorf("normalized search failed: %w", err)
+			}
+			polishResults = filterByHouseNumber(filterBySide(polishSqlResults, params.Side, normalizedParams.HouseNumber), normalizedParams.HouseNumber, params.Limit, params.AssumeAllWhenEmpty)
+		}
+
+		if len(polishResults) > 0 {
+			results = polishResults
+			polishFallbackUsed = true
+			searchType = "polish_characters"
+			fallbackLevel = FallbackLevelPolish
+			tier = "polish_characters"
+		} else if !params.SkipFallback {
+			// Tier 3: Original fallback logic (house_number → street → city-only)
+			tier3Results, tier3FallbackLevel, tier3FallbackMessage, err := executeFallbackSearch(ctx, params, false)
+			if err != nil {
+				return nil, fmt.Errorf("tier 3 fallback failed: %w", err)
+			}
+
+			// Tier 4: Polish normalization fallback logic (only if Tier 3 failed)
+			if len(tier3Results) == 0 && !params.SkipNormalization {
+				tier4Results, tier4FallbackLevel, tier4FallbackMessage, err := executeFallbackSearch(ctx, normalizedParams, true)
+				if err != nil {
+					return nil, fmt.Errorf("tier 4 fallback failed: %w", err)
+				}
+
+				if len(tier4Results) > 0 {
+					results = tier4Results
+					fallbackLevel = tier4FallbackLevel + FallbackLevelPolishHouseNumber - FallbackLevelHouseNumber
+					fallbackMessage = tier4FallbackMessage
+					polishFallbackUsed = true
+					searchType = "polish_characters"
+					tier = "polish_fallback"
+				}
+			} else {
+				results = tier3Results
+				fallbackLevel = tier3FallbackLevel
+				fallbackMessage = tier3FallbackMessage
+				tier = "fallback"
+			}
+		}
+	}
+
+	// Tier 5: Fuzzy city matching (opt-in, only when everything else failed)
+	if len(results) == 0 && params.Fuzzy && params.City != nil && *params.City != "" {
+		fuzzyResponse, err := executeFuzzySearch(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("fuzzy search failed: %w", err)
+		}
+		if fuzzyResponse != nil {
+			annotateMatchQuality(fuzzyResponse.Results, params.City, params.Street)
+			recordTier(ctx, "fuzzy")
+			return fuzzyResponse, nil
+		}
+	}
+
+	// Tier 6: Phonetic city matching (opt-in, only when everything else failed)
+	if len(results) == 0 && params.Phonetic && params.City != nil && *params.City != "" {
+		phoneticResponse, err := executePhoneticSearch(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("phonetic search failed: %w", err)
+		}
+		if phoneticResponse != nil {
+			annotateMatchQuality(phoneticResponse.Results, params.City, params.Street)
+			recordTier(ctx, "phonetic")
+			return phoneticResponse, nil
+		}
+	}
+	if len(results) == 0 {
+		tier = "none"
+	}
+	recordTier(ctx, tier)
+
+	annotateMatchQuality(results, params.City, params.Street)
+
+	response := &SearchResponse{
+		Results:       results,
+		Count:         len(results),
+		SearchType:    searchType,
+		FallbackLevel: fallbackLevel,
+		Exact:         params.Exact,
+	}
+	if params.Province != nil {
+		response.FilteredByProvince = SplitListFilter(*params.Province)
+	}
+	if params.County != nil {
+		response.FilteredByCounty = SplitListFilter(*params.County)
+	}
+
+	if fallbackLevel >= FallbackLevelHouseNumber {
+		response.Message = fallbackMessage
+		response.FallbackUsed = true
+	}
+
+	// Suggest similar city names when the city filter matched nothing
+	if len(results) == 0 && params.City != nil && *params.City != "" {
+		suggestions, err := suggestCities(ctx, *params.City)
+		if err != nil {
+			return nil, fmt.Errorf("city suggestions failed: %w", err)
+		}
+		response.Suggestions = suggestions
+	}
+
+	if len(results) == 0 && isAdministrativeOnlySearch(params) {
+		response.Message = i18n.TranslateContext(ctx, i18n.MsgNoAdministrativeResults, describeAdministrativeFilters(ctx, params))
+	}
+
+	// Explain empty results caused by a county outside the given province
+	if len(results) == 0 && params.Province != nil && params.County != nil {
+		hierarchyMessage, err := explainEmptyHierarchy(ctx, params.Province, params.County)
+		if err != nil {
+			return nil, fmt.Errorf("hierarchy validation failed: %w", err)
+		}
+		if hierarchyMessage != "" {
+			response.Message = hierarchyMessage
+		}
+	}
+
+	if polishFallbackUsed {
+		if response.Message != "" {
+			response.Message += " " + i18n.TranslateContext(ctx, i18n.MsgPolishNormalizedSuffix)
+		} else {
+			response.Message = i18n.TranslateContext(ctx, i18n.MsgPolishNormalized)
+		}
+		response.PolishNormalizationUsed = true
+	}
+
+	// Show what the normalization tiers searched for when they produced the results or when debugging
+	if polishFallbackUsed || debugFromContext(ctx) != nil {
+		response.NormalizedParams = newNormalizedParams(normalizedParams)
+	}
+
+	return response, nil
+}
+
+// CountResponse represents the response for count-only searches
+type CountResponse struct {
+	Count int `json:"count"`
+}
+
+// CountPostalCodes counts postal codes matching the search, trying the exact tier before Polish normalization
+func CountPostalCodes(ctx context.Context, params utils.SearchParams) (*CountResponse, error) {
+	count, err := repository.Count(ctx, params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 && !params.SkipNormalization {
+		count, err = repository.Count(ctx, utils.GetNormalizedSearchParams(params), true)
+		if err != nil {
+			return nil, fmt.Errorf("normalized %w", err)
+		}
+	}
+
+	return &CountResponse{Count: count}, nil
+}
+
+// ErrCoordinatesUnavailable is returned by geospatial queries when the database has no coordinate columns
+var ErrCoordinatesUnavailable = errors.New("coordinates are not available in this database")
+
+// nearestInitialRadiusMeters and nearestMaxRadiusMeters bound the bounding-box pre-filter for nearest searches
+const (
+	nearestInitialRadiusMeters = 2000.0
+	nearestMaxRadiusMeters     = 256000.0
+)
+
+// NearestResult is a postal code record with its distance from the searched point
+type NearestResult struct {
+	database.PostalCode
+	DistanceMeters float64 `json:"distance_meters"`
+}
+
+// NearestResponse represents the response for nearest postal code searches
+type NearestResponse struct {
+	Results   []NearestResult `json:"results"`
+	Count     int             `json:"count"`
+	Latitude  float64         `json:"latitude"`
+	Longitude float64         `json:"longitude"`
+}
+
+// FindNearestPostalCodes returns the records closest to a point ordered by great-circle distance.
+// Candidates are pre-filtered with a bounding box that grows until enough rows are found.
+func FindNearestPostalCodes(ctx context.Context, lat, lon float64, limit int) (*NearestResponse, error) {
+	if !database.HasCoordinates() {
+		return nil, ErrCoordinatesUnavailable
+	}
+
+	db := database.GetDB()
+	var candidates []database.PostalCode
+	for radius := nearestInitialRadiusMeters; radius <= nearestMaxRadiusMeters; radius *= 2 {
+		minLat, maxLat, minLon, maxLon := utils.BoundingBox(lat, lon, radius)
+		query := "SELECT " + database.PostalCodeColumns() + " FROM postal_codes" +
+			" WHERE latitude IS NOT NULL AND longitude IS NOT NULL" +
+			" AND latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?"
+		rows, err := db.QueryContext(ctx, query, minLat, maxLat, minLon, maxLon)
+		if err != nil {
+			return nil, fmt.Errorf("database query failed: %w", err)
+		}
+		candidates, err = database.ScanPostalCodes(rows)
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		// Rows in the box corners may be farther than rows just outside it, so only stop
+		// once the box holds enough candidates within the inscribed circle
+		if countWithinRadius(candidates, lat, lon, radius) >= limit {
+			break
+		}
+	}
+
+	results := make([]NearestResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		distance := utils.HaversineDistance(lat, lon, *candidate.Latitude, *candidate.Longitude)
+		results = append(results, NearestResult{PostalCode: candidate, DistanceMeters: math.Round(distance)})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].DistanceMeters < results[j].DistanceMeters
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return &NearestResponse{
+		Results:   results,
+		Count:     len(results),
+		Latitude:  lat,
+		Longitude: lon,
 	}, nil
 }
 
-// GetCounties gets counties, optionally filtered by province and/or prefix
-func GetCounties(province, prefix *string) (*CountyResponse, error) {
+// SearchWithinBoundingBox returns records whose coordinates lie within the given rectangle
+func SearchWithinBoundingBox(ctx context.Context, minLat, maxLat, minLon, maxLon float64, limit int) (*SearchResponse, error) {
+	if !database.HasCoordinates() {
+		return nil, ErrCoordinatesUnavailable
+	}
+
 	db := database.GetDB()
-	query := "SELECT DISTINCT county FROM postal_codes WHERE county IS NOT NULL"
-	var args []interface{}
+	query := "SELECT " + database.PostalCodeColumns() + " FROM postal_codes" +
+		" WHERE latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?" +
+		" ORDER BY postal_code, id LIMIT ?"
 
-	if province != nil && *province != "" {
-		query += " AND province = ? COLLATE NOCASE"
-		args = append(args, *province)
+	// Fetch one extra row to detect whether the result was truncated
+	rows, err := db.QueryContext(ctx, query, minLat, maxLat, minLon, maxLon, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	results, err := database.ScanPostalCodes(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
 	}
 
-	query += " ORDER BY county"
+	response := &SearchResponse{SearchType: "bounding_box"}
+	if len(results) > limit {
+		results = results[:limit]
+		response.Message = i18n.TranslateContext(ctx, i18n.MsgBoundingBoxTruncated, limit)
+	}
+	response.Results = results
+	response.Count = len(results)
 
-	rows, err := db.Query(query, args...)
+	return response, nil
+}
+
+// MaxSampleSize caps the number of rows returned by SamplePostalCodes
+const MaxSampleSize = 100
+
+// SamplePostalCodes returns n random records, for test fixtures and client smoke tests.
+// ORDER BY RANDOM() scans the whole table, which is acceptable at this dataset size.
+func SamplePostalCodes(ctx context.Context, n int) (*SearchResponse, error) {
+	db := database.GetDB()
+	query := "SELECT " + database.PostalCodeColumns() + " FROM postal_codes ORDER BY RANDOM() LIMIT ?"
+	rows, err := db.QueryContext(ctx, query, n)
 	if err != nil {
 		return nil, fmt.Errorf("database query failed: %w", err)
 	}
 	defer rows.Close()
 
-	var allCounties []string
-	for rows.Next() {
-		var county string
-		if err := rows.Scan(&county); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
-		}
-		allCounties = append(allCounties, county)
+	results, err := database.ScanPostalCodes(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
 	}
 
-	var filteredCounties []string
-	if prefix != nil && *prefix != "" {
-		normalizedPrefix := strings.ToLower(utils.NormalizePolishText(*prefix))
-		originalPrefix := strings.ToLower(*prefix)
-
-		for _, county := range allCounties {
-			countyLower := strings.ToLower(county)
-			normalizedCounty := strings.ToLower(utils.NormalizePolishText(county))
-			if strings.HasPrefix(countyLower, originalPrefix) || strings.HasPrefix(normalizedCounty, normalizedPrefix) {
-				filteredCounties = append(filteredCounties, county)
-			}
+	return &SearchResponse{
+		Results:    results,
+		Count:      len(results),
+		SearchType: "sample",
+	}, nil
+}
+
+// countWithinRadius counts records whose coordinates lie within the radius of the point
+func countWithinRadius(records []database.PostalCode, lat, lon, radius float64) int {
+	count := 0
+	for _, record := range records {
+		if utils.HaversineDistance(lat, lon, *record.Latitude, *record.Longitude) <= radius {
+			count++
 		}
-	} else {
-		filteredCounties = allCounties
 	}
+	return count
+}
 
-	return &CountyResponse{
-		Counties:           filteredCounties,
-		Count:              len(filteredCounties),
-		FilteredByProvince: province,
-		FilteredByPrefix:   prefix,
+// GetPostalCodeByCode gets postal code records by postal code
+func GetPostalCodeByCode(ctx context.Context, postalCode string) (*SearchResponse, error) {
+	results, err := repository.GetByCode(ctx, postalCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &SearchResponse{
+		Results: results,
+		Count:   len(results),
 	}, nil
 }
 
-// GetMunicipalities gets municipalities, optionally filtered by province, county, and/or prefix
-func GetMunicipalities(province, county, prefix *string) (*MunicipalityResponse, error) {
-	db := database.GetDB()
-	query := "SELECT DISTINCT municipality FROM postal_codes WHERE municipality IS NOT NULL"
-	var args []interface{}
+// Location lists are effectively static, so they are cached until the TTL expires
+var (
+	provincesCache       = cache.New[*ProvinceResponse](config.LocationCacheTTL())
+	countiesCache        = cache.New[*CountyResponse](config.LocationCacheTTL())
+	municipalitiesCache  = cache.New[*MunicipalityResponse](config.LocationCacheTTL())
+	citiesCache          = cache.New[*CityResponse](config.LocationCacheTTL())
+	multiCodeCache       = cache.New[*MultiCodeCityResponse](config.LocationCacheTTL())
+	streetsCache         = cache.New[*StreetResponse](config.LocationCacheTTL())
+	detailedStreetsCache = cache.New[*DetailedStreetResponse](config.LocationCacheTTL())
+)
 
-	if province != nil && *province != "" {
-		query += " AND province = ? COLLATE NOCASE"
-		args = append(args, *province)
+// locationCacheKey builds a cache key from optional filter values
+func locationCacheKey(filters ...*string) string {
+	parts := make([]string, len(filters))
+	for i, filter := range filters {
+		if filter != nil {
+			parts[i] = *filter
+		}
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// matchesNameFilter reports whether a name starts with the filter, or contains it when contains is set.
+// Both sides are compared lowercased, as written and with Polish characters normalized.
+func matchesNameFilter(name, filter string, contains bool) bool {
+	match := strings.HasPrefix
+	if contains {
+		match = strings.Contains
+	}
+	return match(strings.ToLower(name), strings.ToLower(filter)) ||
+		match(strings.ToLower(utils.NormalizePolishText(name)), strings.ToLower(utils.NormalizePolishText(filter)))
+}
+
+// nameFilterPattern returns the LIKE pattern for a normalized name filter, anchored at the start unless contains is set
+func nameFilterPattern(filter string, contains bool) string {
+	pattern := utils.NormalizePolishText(filter) + "%"
+	if contains {
+		pattern = "%" + pattern
+	}
+	return pattern
+}
+
+// polishLetters are the letters normalizedNameColumn replaces with their ASCII equivalents
+const polishLetters = "ąćęłńóśźżĄĆĘŁŃÓŚŹŻ"
+
+// normalizedNameColumn is the SQL for a name column without a stored normalized copy, with Polish letters replaced
+// like utils.NormalizePolishText and lowercased. lower() only folds ASCII in SQLite, hence the replacements first.
+func normalizedNameColumn(column string) string {
+	expr := column
+	for _, letter := range polishLetters {
+		expr = fmt.Sprintf("REPLACE(%s, '%c', '%s')", expr, letter, utils.NormalizePolishText(string(letter)))
 	}
+	return "lower(" + expr + ")"
+}
 
-	if county != nil && *county != "" {
-		query += " AND county = ? COLLATE NOCASE"
-		args = append(args, *county)
+// filterDistinctNames narrows the FROM clause of a location list to names matching the prefix filter, or containing
+// it when contains is set, ignoring case and Polish characters like matchesNameFilter. Without a normalized column
+// the comparison is costly, so it runs on the distinct names of a subquery instead of on every row; the subquery's
+// LIMIT keeps SQLite from pushing the comparison back into it.
+func filterDistinctNames(from string, args []interface{}, column string, prefix *string, contains bool) (string, []interface{}) {
+	if prefix == nil || *prefix == "" {
+		return from, args
 	}
+	from = fmt.Sprintf("FROM (SELECT DISTINCT %s %s LIMIT %d) AS names WHERE %s LIKE ?", column, from, int64(math.MaxInt64), normalizedNameColumn(column))
+	return from, append(args, strings.ToLower(nameFilterPattern(*prefix, contains)))
+}
 
-	query += " ORDER BY municipality"
+// ListOptions controls ordering and paging of location lists
+type ListOptions struct {
+	Limit      int
+	Offset     int
+	LocaleSort bool
+	Sort       string
+	Descending bool
+
+	// IncludePopulation returns cities as objects with their population
+	IncludePopulation bool
+	// IncludePostalCodes returns streets as objects with their postal codes
+	IncludePostalCodes bool
+	// Contains matches the prefix filter anywhere in a name instead of only at its start
+	Contains bool
+}
+
+// cacheKey distinguishes cached pages of the same filtered list
+func (o ListOptions) cacheKey() string {
+	return fmt.Sprintf("\x00%d\x00%d\x00%t\x00%s\x00%t\x00%t\x00%t\x00%t", o.Limit, o.Offset, o.LocaleSort, o.Sort, o.Descending, o.IncludePopulation, o.IncludePostalCodes, o.Contains)
+}
 
-	rows, err := db.Query(query, args...)
+// citySortColumns whitelists the sort keys accepted by the cities list and maps them to columns
+var citySortColumns = map[string]string{
+	"population": "MAX(population)",
+	"alpha":      "city_clean",
+}
+
+// cityOrderBy builds the ORDER BY clause of the cities list; locale order is applied in Go afterwards
+func cityOrderBy(opts ListOptions) string {
+	column, ok := citySortColumns[opts.Sort]
+	if !ok {
+		return "MAX(population) DESC, city_clean"
+	}
+
+	direction := "ASC"
+	if opts.Descending {
+		direction = "DESC"
+	}
+	if column == "city_clean" {
+		return "city_clean " + direction
+	}
+	return fmt.Sprintf("%s %s, city_clean", column, direction)
+}
+
+// paginate returns the page of items selected by the list options
+func paginate[T any](items []T, opts ListOptions) []T {
+	if opts.Offset >= len(items) {
+		return []T{}
+	}
+	end := len(items)
+	if opts.Limit > 0 && opts.Offset+opts.Limit < end {
+		end = opts.Offset + opts.Limit
+	}
+	return items[opts.Offset:end]
+}
+
+// queryLocationPage runs a grouped location query and returns one page plus the total number of values.
+// Grouping instead of DISTINCT lets orderBy use aggregates such as MAX(population) portably.
+// Database order is paged with SQL LIMIT/OFFSET; Polish collation is not available in SQLite,
+// so locale order loads the full list and pages it in Go.
+func queryLocationPage(ctx context.Context, column, from string, args []interface{}, orderBy string, opts ListOptions) ([]string, int, error) {
+	db := database.GetDB()
+
+	query := fmt.Sprintf("SELECT %s %s GROUP BY %s ORDER BY %s", column, from, column, orderBy)
+	queryArgs := args
+	total := 0
+	if !opts.LocaleSort {
+		countQuery := fmt.Sprintf("SELECT COUNT(DISTINCT %s) %s", column, from)
+		start := time.Now()
+		if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("database query failed: %w", err)
+		}
+		logSlowQuery(start, countQuery, args)
+		query += " LIMIT ? OFFSET ?"
+		queryArgs = append(append([]interface{}{}, args...), opts.Limit, opts.Offset)
+	}
+
+	defer logSlowQuery(time.Now(), query, queryArgs)
+	rows, err := db.QueryContext(ctx, query, queryArgs...)
 	if err != nil {
-		return nil, fmt.Errorf("database query failed: %w", err)
+		return nil, 0, fmt.Errorf("database query failed: %w", err)
 	}
 	defer rows.Close()
 
-	var allMunicipalities []string
+	values := []string{}
 	for rows.Next() {
-		var municipality string
-		if err := rows.Scan(&municipality); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
 		}
-		allMunicipalities = append(allMunicipalities, municipality)
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	if opts.LocaleSort {
+		sorted := utils.SortedPolish(values)
+		if opts.Descending {
+			slices.Reverse(sorted)
+		}
+		return paginate(sorted, opts), len(values), nil
+	}
+	return values, total, nil
+}
+
+// GetProvinces gets all provinces, optionally filtered by prefix, or by substring when contains is set
+func GetProvinces(ctx context.Context, prefix *string, contains bool) (*ProvinceResponse, error) {
+	key := locationCacheKey(prefix) + fmt.Sprintf("\x00%t", contains)
+	if cached, ok := provincesCache.Get(key); ok {
+		return cached, nil
 	}
 
-	var filteredMunicipalities []string
+	allProvinces, err := repository.ListProvinces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var filteredProvinces []string
 	if prefix != nil && *prefix != "" {
-		normalizedPrefix := strings.ToLower(utils.NormalizePolishText(*prefix))
-		originalPrefix := strings.ToLower(*prefix)
-
-		for _, municipality := range allMunicipalities {
-			municipalityLower := strings.ToLower(municipality)
-			normalizedMunicipality := strings.ToLower(utils.NormalizePolishText(municipality))
-			if strings.HasPrefix(municipalityLower, originalPrefix) || strings.HasPrefix(normalizedMunicipality, normalizedPrefix) {
-				filteredMunicipalities = append(filteredMunicipalities, municipality)
+		for _, province := range allProvinces {
+			if matchesNameFilter(province, *prefix, contains) {
+				filteredProvinces = append(filteredProvinces, province)
 			}
 		}
 	} else {
-		filteredMunicipalities = allMunicipalities
+		filteredProvinces = allProvinces
+	}
+
+	response := &ProvinceResponse{
+		Provinces:        filteredProvinces,
+		Count:            len(filteredProvinces),
+		FilteredByPrefix: prefix,
+	}
+	provincesCache.Set(key, response)
+	return response, nil
+}
+
+// GetCounties gets counties, optionally filtered by province and/or prefix
+func GetCounties(ctx context.Context, province, prefix *string, opts ListOptions) (*CountyResponse, error) {
+	key := locationCacheKey(province, prefix) + opts.cacheKey()
+	if cached, ok := countiesCache.Get(key); ok {
+		return cached, nil
+	}
+
+	page, total, err := repository.ListCounties(ctx, province, prefix, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	message := ""
+	if total == 0 {
+		message, err = emptyLocationMessage(ctx, i18n.MsgNoCountiesFound, province, nil)
+		if err != nil {
+			return nil, fmt.Errorf("hierarchy validation failed: %w", err)
+		}
+	}
+
+	response := &CountyResponse{
+		Counties:           page,
+		Count:              len(page),
+		Total:              total,
+		Limit:              opts.Limit,
+		Offset:             opts.Offset,
+		FilteredByProvince: province,
+		FilteredByPrefix:   prefix,
+		Message:            message,
+	}
+	countiesCache.Set(key, response)
+	return response, nil
+}
+
+// GetMunicipalities gets municipalities, optionally filtered by province, county, and/or prefix
+func GetMunicipalities(ctx context.Context, province, county, prefix *string, opts ListOptions) (*MunicipalityResponse, error) {
+	key := locationCacheKey(province, county, prefix) + opts.cacheKey()
+	if cached, ok := municipalitiesCache.Get(key); ok {
+		return cached, nil
+	}
+
+	page, total, err := repository.ListMunicipalities(ctx, province, county, prefix, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	return &MunicipalityResponse{
-		Municipalities:     filteredMunicipalities,
-		Count:              len(filteredMunicipalities),
+	message := ""
+	if total == 0 {
+		message, err = emptyLocationMessage(ctx, i18n.MsgNoMunicipalitiesFound, province, county)
+		if err != nil {
+			return nil, fmt.Errorf("hierarchy validation failed: %w", err)
+		}
+	}
+
+	response := &MunicipalityResponse{
+		Municipalities:     page,
+		Count:              len(page),
+		Total:              total,
+		Limit:              opts.Limit,
+		Offset:             opts.Offset,
 		FilteredByProvince: province,
 		FilteredByCounty:   county,
 		FilteredByPrefix:   prefix,
-	}, nil
+		Message:            message,
+	}
+	municipalitiesCache.Set(key, response)
+	return response, nil
 }
 
 // GetCities gets cities, optionally filtered by province, county, municipality, and/or prefix
-func GetCities(province, county, municipality, prefix *string) (*CityResponse, error) {
-	db := database.GetDB()
-	query := "SELECT DISTINCT city_clean FROM postal_codes WHERE city_clean IS NOT NULL"
+func GetCities(ctx context.Context, province, county, municipality, prefix *string, opts ListOptions) (*CityResponse, error) {
+	key := locationCacheKey(province, county, municipality, prefix) + opts.cacheKey()
+	if cached, ok := citiesCache.Get(key); ok {
+		return cached, nil
+	}
+
+	from := "FROM postal_codes WHERE city_clean IS NOT NULL"
 	var args []interface{}
 
-	if province != nil && *province != "" {
-		query += " AND province = ? COLLATE NOCASE"
-		args = append(args, *province)
-	}
+	from, args = appendListFilter(from, args, "province", province)
 
-	if county != nil && *county != "" {
-		query += " AND county = ? COLLATE NOCASE"
-		args = append(args, *county)
-	}
+	from, args = appendListFilter(from, args, "county", county)
 
 	if municipality != nil && *municipality != "" {
-		query += " AND municipality = ? COLLATE NOCASE"
+		from += " AND municipality = ? COLLATE NOCASE"
 		args = append(args, *municipality)
 	}
 
 	if prefix != nil && *prefix != "" {
-		normalizedPrefix := utils.NormalizePolishText(*prefix)
-		query += " AND city_normalized LIKE ? COLLATE NOCASE"
-		args = append(args, normalizedPrefix+"%")
+		from += " AND city_normalized LIKE ? COLLATE NOCASE"
+		args = append(args, nameFilterPattern(*prefix, opts.Contains))
 	}
 
-	query += " ORDER BY population DESC, city_clean"
+	cities, total, err := queryLocationPage(ctx, "city_clean", from, args, cityOrderBy(opts), opts)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := db.Query(query, args...)
+	var withPopulation []CityPopulation
+	if opts.IncludePopulation {
+		withPopulation, err = cityPopulations(ctx, from, args, cities)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	message := ""
+	if total == 0 {
+		message, err = emptyLocationMessage(ctx, i18n.MsgNoCitiesFound, province, county)
+		if err != nil {
+			return nil, fmt.Errorf("hierarchy validation failed: %w", err)
+		}
+	}
+
+	response := &CityResponse{
+		Cities:                 cities,
+		Count:                  len(cities),
+		Total:                  total,
+		Limit:                  opts.Limit,
+		Offset:                 opts.Offset,
+		FilteredByProvince:     province,
+		FilteredByCounty:       county,
+		FilteredByMunicipality: municipality,
+		FilteredByPrefix:       prefix,
+		Message:                message,
+		withPopulation:         withPopulation,
+	}
+	citiesCache.Set(key, response)
+	return response, nil
+}
+
+// cityPopulations attaches the largest recorded population to each city of a page, reusing the list's filters
+func cityPopulations(ctx context.Context, from string, args []interface{}, cities []string) ([]CityPopulation, error) {
+	withPopulation := make([]CityPopulation, len(cities))
+	if len(cities) == 0 {
+		return withPopulation, nil
+	}
+
+	placeholders := make([]string, len(cities))
+	queryArgs := append([]interface{}{}, args...)
+	for i, city := range cities {
+		placeholders[i] = "?"
+		queryArgs = append(queryArgs, city)
+	}
+	query := fmt.Sprintf("SELECT city_clean, MAX(population) %s AND city_clean IN (%s) GROUP BY city_clean", from, strings.Join(placeholders, ", "))
+
+	defer logSlowQuery(time.Now(), query, queryArgs)
+	rows, err := database.GetDB().QueryContext(ctx, query, queryArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("database query failed: %w", err)
 	}
 	defer rows.Close()
 
-	var cities []string
+	populations := make(map[string]int)
 	for rows.Next() {
 		var city string
-		if err := rows.Scan(&city); err != nil {
+		var population sql.NullInt64
+		if err := rows.Scan(&city, &population); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		cities = append(cities, city)
+		if population.Valid {
+			populations[city] = int(population.Int64)
+		}
 	}
 
-	return &CityResponse{
-		Cities:                 cities,
-		Count:                  len(cities),
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	// Unknown populations are left nil and omitted from the JSON
+	for i, city := range cities {
+		withPopulation[i].City = city
+		if population, ok := populations[city]; ok {
+			withPopulation[i].Population = &population
+		}
+	}
+	return withPopulation, nil
+}
+
+// MultiCodeCity is a city served by more than one postal code
+type MultiCodeCity struct {
+	City            string `json:"city"`
+	County          string `json:"county"`
+	Province        string `json:"province"`
+	PostalCodeCount int    `json:"postal_code_count"`
+}
+
+// MultiCodeCityResponse represents the response for cities spanning multiple postal codes
+type MultiCodeCityResponse struct {
+	Cities             []MultiCodeCity `json:"cities"`
+	Count              int             `json:"count"`
+	Total              int             `json:"total"`
+	Limit              int             `json:"limit"`
+	Offset             int             `json:"offset"`
+	FilteredByProvince *string         `json:"filtered_by_province,omitempty"`
+}
+
+// GetMultiCodeCities lists cities with more than one distinct postal code, most codes first unless
+// opts.Sort is "alpha". Cities are told apart by county and province since village names repeat.
+func GetMultiCodeCities(ctx context.Context, province *string, opts ListOptions) (*MultiCodeCityResponse, error) {
+	key := locationCacheKey(province) + opts.cacheKey()
+	if cached, ok := multiCodeCache.Get(key); ok {
+		return cached, nil
+	}
+
+	counts, total, err := listGroupedCityCounts(ctx, "", "COUNT(DISTINCT postal_code)", "> 1", province, opts)
+	if err != nil {
+		return nil, err
+	}
+	cities := make([]MultiCodeCity, len(counts))
+	for i, count := range counts {
+		cities[i] = MultiCodeCity{City: count.City, County: count.County, Province: count.Province, PostalCodeCount: count.Count}
+	}
+
+	response := &MultiCodeCityResponse{
+		Cities:             cities,
+		Count:              len(cities),
+		Total:              total,
+		Limit:              opts.Limit,
+		Offset:             opts.Offset,
+		FilteredByProvince: province,
+	}
+	multiCodeCache.Set(key, response)
+	return response, nil
+}
+
+// GetStreets gets streets, optionally filtered by city, province, county, municipality, prefix and/or postal code
+func GetStreets(ctx context.Context, city, province, county, municipality, prefix, postalCode *string, opts ListOptions) (*StreetResponse, error) {
+	key := locationCacheKey(city, province, county, municipality, prefix, postalCode) + opts.cacheKey()
+	if cached, ok := streetsCache.Get(key); ok {
+		return cached, nil
+	}
+
+	from, args := streetListFilter(city, province, county, municipality, prefix, postalCode, opts.Contains)
+	streets, total, err := queryLocationPage(ctx, "street", from, args, "street", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var withPostalCodes []StreetPostalCodes
+	if opts.IncludePostalCodes {
+		withPostalCodes, err = streetPostalCodes(ctx, from, args, streets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response := &StreetResponse{
+		Streets:                streets,
+		Count:                  len(streets),
+		Total:                  total,
+		Limit:                  opts.Limit,
+		Offset:                 opts.Offset,
+		FilteredByCity:         city,
 		FilteredByProvince:     province,
 		FilteredByCounty:       county,
 		FilteredByMunicipality: municipality,
 		FilteredByPrefix:       prefix,
-	}, nil
+		FilteredByPostalCode:   postalCode,
+		withPostalCodes:        withPostalCodes,
+	}
+	streetsCache.Set(key, response)
+	return response, nil
 }
 
-// GetStreets gets streets, optionally filtered by city, province, county, municipality, and/or prefix
-func GetStreets(city, province, county, municipality, prefix *string) (*StreetResponse, error) {
-	db := database.GetDB()
-	query := "SELECT DISTINCT street FROM postal_codes WHERE street IS NOT NULL AND street != ''"
+// streetPostalCodes attaches the distinct postal codes of each street of a page, reusing the list's filters
+func streetPostalCodes(ctx context.Context, from string, args []interface{}, streets []string) ([]StreetPostalCodes, error) {
+	withPostalCodes := make([]StreetPostalCodes, len(streets))
+	if len(streets) == 0 {
+		return withPostalCodes, nil
+	}
+
+	placeholders := make([]string, len(streets))
+	queryArgs := append([]interface{}{}, args...)
+	for i, street := range streets {
+		placeholders[i] = "?"
+		queryArgs = append(queryArgs, street)
+	}
+	query := fmt.Sprintf("SELECT street, postal_code, MAX(%s) %s AND street IN (%s) GROUP BY street, postal_code ORDER BY street, postal_code",
+		hasHouseNumbersColumn, from, strings.Join(placeholders, ", "))
+
+	defer logSlowQuery(time.Now(), query, queryArgs)
+	rows, err := database.GetDB().QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	byStreet := make(map[string]StreetPostalCodes)
+	for rows.Next() {
+		var street, postalCode string
+		var numbered int
+		if err := rows.Scan(&street, &postalCode, &numbered); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		entry := byStreet[street]
+		entry.PostalCodes = append(entry.PostalCodes, postalCode)
+		entry.HasHouseNumbers = entry.HasHouseNumbers || numbered == 1
+		byStreet[street] = entry
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	for i, street := range streets {
+		entry := byStreet[street]
+		entry.Street = street
+		withPostalCodes[i] = entry
+	}
+	return withPostalCodes, nil
+}
+
+// streetListFilter builds the FROM clause and arguments shared by the street lists; contains matches the prefix
+// anywhere in the street name
+func streetListFilter(city, province, county, municipality, prefix, postalCode *string, contains bool) (string, []interface{}) {
+	from := "FROM postal_codes WHERE street IS NOT NULL AND street != ''"
 	var args []interface{}
 
 	if city != nil && *city != "" {
 		normalizedCity := utils.NormalizePolishText(*city)
-		query += " AND city_normalized = ? COLLATE NOCASE"
+		from += " AND city_normalized = ? COLLATE NOCASE"
 		args = append(args, normalizedCity)
 	}
 
-	if province != nil && *province != "" {
-		query += " AND province = ? COLLATE NOCASE"
-		args = append(args, *province)
-	}
+	from, args = appendListFilter(from, args, "province", province)
 
-	if county != nil && *county != "" {
-		query += " AND county = ? COLLATE NOCASE"
-		args = append(args, *county)
-	}
+	from, args = appendListFilter(from, args, "county", county)
 
 	if municipality != nil && *municipality != "" {
-		query += " AND municipality = ? COLLATE NOCASE"
+		from += " AND municipality = ? COLLATE NOCASE"
 		args = append(args, *municipality)
 	}
 
 	if prefix != nil && *prefix != "" {
-		normalizedPrefix := utils.NormalizePolishText(*prefix)
-		query += " AND street_normalized LIKE ? COLLATE NOCASE"
-		args = append(args, normalizedPrefix+"%")
+		from += " AND street_normalized LIKE ? COLLATE NOCASE"
+		args = append(args, nameFilterPattern(*prefix, contains))
 	}
 
-	query += " ORDER BY street"
+	if postalCode != nil && *postalCode != "" {
+		from += " AND postal_code = ?"
+		args = append(args, *postalCode)
+	}
+	return from, args
+}
+
+// GetDetailedStreets gets streets paired with their distinct postal codes, with the same filters as GetStreets.
+// The street and postal code pairs are read in one query and grouped in Go, so every page loads the full list.
+func GetDetailedStreets(ctx context.Context, city, province, county, municipality, prefix, postalCode *string, opts ListOptions) (*DetailedStreetResponse, error) {
+	key := locationCacheKey(city, province, county, municipality, prefix, postalCode) + opts.cacheKey()
+	if cached, ok := detailedStreetsCache.Get(key); ok {
+		return cached, nil
+	}
 
-	rows, err := db.Query(query, args...)
+	from, args := streetListFilter(city, province, county, municipality, prefix, postalCode, opts.Contains)
+	query := "SELECT street, postal_code, MAX(" + hasHouseNumbersColumn + ") " + from + " GROUP BY street, postal_code ORDER BY street, postal_code"
+	defer logSlowQuery(time.Now(), query, args)
+	rows, err := database.GetDB().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("database query failed: %w", err)
 	}
 	defer rows.Close()
 
-	var streets []string
+	streets := []StreetPostalCodes{}
 	for rows.Next() {
-		var street string
-		if err := rows.Scan(&street); err != nil {
+		var street, postalCode string
+		var numbered int
+		if err := rows.Scan(&street, &postalCode, &numbered); err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
-		streets = append(streets, street)
+		// Rows arrive ordered by street, so each street's codes are consecutive
+		if last := len(streets) - 1; last >= 0 && streets[last].Street == street {
+			streets[last].PostalCodes = append(streets[last].PostalCodes, postalCode)
+			streets[last].HasHouseNumbers = streets[last].HasHouseNumbers || numbered == 1
+			continue
+		}
+		streets = append(streets, StreetPostalCodes{Street: street, PostalCodes: []string{postalCode}, HasHouseNumbers: numbered == 1})
 	}
 
-	return &StreetResponse{
-		Streets:                streets,
-		Count:                  len(streets),
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	if opts.LocaleSort {
+		streets = sortStreetsPolish(streets)
+	}
+	page := paginate(streets, opts)
+
+	response := &DetailedStreetResponse{
+		Streets:                page,
+		Count:                  len(page),
+		Total:                  len(streets),
+		Limit:                  opts.Limit,
+		Offset:                 opts.Offset,
 		FilteredByCity:         city,
 		FilteredByProvince:     province,
 		FilteredByCounty:       county,
 		FilteredByMunicipality: municipality,
 		FilteredByPrefix:       prefix,
-	}, nil
+		FilteredByPostalCode:   postalCode,
+	}
+	detailedStreetsCache.Set(key, response)
+	return response, nil
+}
+
+// GetStreetHouseNumbering counts a street's records and those listing house number ranges, so forms can
+// decide whether to ask for a house number. The city and street are compared Polish-normalized and ignoring case,
+// and the street's prefix is ignored, so "ul. Floriańska" and "FLORIANSKA" both find "Floriańska".
+// It returns nil when the city has no such street.
+func GetStreetHouseNumbering(ctx context.Context, city, street string) (*StreetHouseNumbering, error) {
+	condition, streetArgs := streetNameCondition("street_normalized", street, true)
+	query := "SELECT COUNT(*), COALESCE(SUM(" + hasHouseNumbersColumn + "), 0) FROM postal_codes WHERE city_normalized = ? COLLATE NOCASE" + condition
+	args := append([]interface{}{utils.NormalizePolishText(city)}, streetArgs...)
+
+	response := &StreetHouseNumbering{City: city, Street: street}
+	start := time.Now()
+	if err := database.GetDB().QueryRowContext(ctx, query, args...).Scan(&response.Records, &response.NumberedRecords); err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	logSlowQuery(start, query, args)
+
+	if response.Records == 0 {
+		return nil, nil
+	}
+	response.HasHouseNumbers = response.NumberedRecords > 0
+	return response, nil
+}
+
+// sortStreetsPolish orders streets by Polish collation of their names
+func sortStreetsPolish(streets []StreetPostalCodes) []StreetPostalCodes {
+	byName := make(map[string]StreetPostalCodes, len(streets))
+	names := make([]string, len(streets))
+	for i, street := range streets {
+		byName[street.Street] = street
+		names[i] = street.Street
+	}
+
+	sorted := make([]StreetPostalCodes, len(streets))
+	for i, name := range utils.SortedPolish(names) {
+		sorted[i] = byName[name]
+	}
+	return sorted
 }
\ No newline at end of file
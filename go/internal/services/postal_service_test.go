@@ -0,0 +1,786 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"postal-api/internal/database"
+	"postal-api/internal/i18n"
+	"postal-api/internal/utils"
+)
+
+// testDBPath points at the shared database in the project root
+const testDBPath = "../../../postal_codes.db"
+
+// openTestDatabase connects the services to the shared test database, skipping the test when it is missing.
+// Tests running against fake repositories or temporary databases do not need it.
+func openTestDatabase(tb testing.TB) {
+	tb.Helper()
+	if _, err := os.Stat(testDBPath); err != nil {
+		tb.Skip("Database file postal_codes.db not found")
+	}
+	if err := database.InitializeWithPath(testDBPath); err != nil {
+		tb.Fatalf("failed to initialize database: %v", err)
+	}
+	tb.Cleanup(func() { database.Close() })
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func strValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func TestSearchPostalCodesOrderingIsDeterministic(t *testing.T) {
+	openTestDatabase(t)
+	params := utils.SearchParams{
+		City:  strPtr("Warszawa"),
+		Limit: 50,
+	}
+
+	first, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("first search failed: %v", err)
+	}
+	second, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("second search failed: %v", err)
+	}
+
+	if first.Count == 0 {
+		t.Fatal("expected results for Warszawa")
+	}
+	if first.Count != second.Count {
+		t.Fatalf("result counts differ: %d vs %d", first.Count, second.Count)
+	}
+
+	for i := range first.Results {
+		a, b := first.Results[i], second.Results[i]
+		if a.PostalCode != b.PostalCode || a.City != b.City || strValue(a.Street) != strValue(b.Street) || strValue(a.HouseNumbers) != strValue(b.HouseNumbers) {
+			t.Fatalf("row %d differs between runs: %+v vs %+v", i, a, b)
+		}
+	}
+}
+
+func TestSearchPostalCodesCancelledContext(t *testing.T) {
+	openTestDatabase(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	params := utils.SearchParams{
+		Street: strPtr("nonexistent street name"),
+		Limit:  10,
+	}
+
+	start := time.Now()
+	_, err := SearchPostalCodes(ctx, params)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("cancelled search took %v, expected it to return promptly", elapsed)
+	}
+}
+
+func TestGetStreetsCancelledContextIsNotCached(t *testing.T) {
+	openTestDatabase(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	city := strPtr("Kraków")
+	if _, err := GetStreets(ctx, city, nil, nil, nil, nil, nil, ListOptions{Limit: 100}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	response, err := GetStreets(context.Background(), city, nil, nil, nil, nil, nil, ListOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("streets lookup failed: %v", err)
+	}
+	if response.Count == 0 {
+		t.Fatal("expected streets for Kraków after a cancelled lookup")
+	}
+}
+
+func BenchmarkGetProvincesUncached(b *testing.B) {
+	openTestDatabase(b)
+	for i := 0; i < b.N; i++ {
+		provincesCache.Clear()
+		if _, err := GetProvinces(context.Background(), nil, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetProvincesCached(b *testing.B) {
+	openTestDatabase(b)
+	if _, err := GetProvinces(context.Background(), nil, false); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := GetProvinces(context.Background(), nil, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestExplainEmptyHierarchy(t *testing.T) {
+	openTestDatabase(t)
+	cases := []struct {
+		province, county string
+		want             string
+	}{
+		{"Mazowieckie", "Krakowski", "County 'Krakowski' does not belong to province 'Mazowieckie'; it is in małopolskie."},
+		{"mazowieckie", "Nowhere", "County 'Nowhere' does not exist."},
+		{"Atlantis", "", "Province 'Atlantis' does not exist."},
+		{"małopolskie", "krakowski", ""},
+	}
+
+	for _, tc := range cases {
+		got, err := explainEmptyHierarchy(context.Background(), strPtr(tc.province), strPtr(tc.county))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("explainEmptyHierarchy(%q, %q) = %q, want %q", tc.province, tc.county, got, tc.want)
+		}
+	}
+
+	polish := i18n.WithLanguage(context.Background(), i18n.Polish)
+	if got, _ := explainEmptyHierarchy(polish, strPtr("Atlantis"), nil); got != "Województwo 'Atlantis' nie istnieje." {
+		t.Errorf("expected the explanation in Polish, got %q", got)
+	}
+}
+
+func TestCityOrderBy(t *testing.T) {
+	cases := []struct {
+		opts ListOptions
+		want string
+	}{
+		{ListOptions{}, "MAX(population) DESC, city_clean"},
+		{ListOptions{Sort: "population"}, "MAX(population) ASC, city_clean"},
+		{ListOptions{Sort: "population", Descending: true}, "MAX(population) DESC, city_clean"},
+		{ListOptions{Sort: "alpha"}, "city_clean ASC"},
+		{ListOptions{Sort: "alpha", Descending: true}, "city_clean DESC"},
+		{ListOptions{Sort: "city_clean; DROP TABLE postal_codes"}, "MAX(population) DESC, city_clean"},
+	}
+
+	for _, tc := range cases {
+		if got := cityOrderBy(tc.opts); got != tc.want {
+			t.Errorf("cityOrderBy(%+v) = %q, want %q", tc.opts, got, tc.want)
+		}
+	}
+}
+
+func TestGetCitiesIncludePopulation(t *testing.T) {
+	openTestDatabase(t)
+	opts := ListOptions{Limit: 2, IncludePopulation: true}
+	response, err := GetCities(context.Background(), strPtr("opolskie"), nil, nil, nil, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+
+	var decoded struct {
+		Cities []CityPopulation `json:"cities"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("expected city objects, got %s", body)
+	}
+	if len(decoded.Cities) != 2 || decoded.Cities[0].City != "Opole" || decoded.Cities[0].Population == nil {
+		t.Fatalf("expected Opole with population first, got %s", body)
+	}
+}
+
+func TestSamplePostalCodesReturnsRequestedCount(t *testing.T) {
+	openTestDatabase(t)
+	response, err := SamplePostalCodes(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("SamplePostalCodes failed: %v", err)
+	}
+	if response.Count != 3 || len(response.Results) != 3 {
+		t.Errorf("expected 3 records, got count=%d results=%d", response.Count, len(response.Results))
+	}
+}
+
+func TestSearchPostalCodesRecordsDebugQueries(t *testing.T) {
+	openTestDatabase(t)
+	ctx, info := WithDebug(context.Background(), false)
+	params := utils.SearchParams{City: strPtr("Krakow"), Street: strPtr("Dluga"), Limit: 5}
+	if _, err := SearchPostalCodes(ctx, params); err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+
+	if info.Tier != "polish_characters" {
+		t.Errorf("expected tier polish_characters, got %q", info.Tier)
+	}
+	if len(info.Queries) != 2 || info.Queries[0].Tier != "exact" || info.Queries[1].Tier != "polish_characters" {
+		t.Fatalf("expected exact and polish_characters queries, got %+v", info.Queries)
+	}
+	if info.Queries[0].Rows != 0 || info.Queries[1].Rows == 0 {
+		t.Errorf("unexpected row counts: %+v", info.Queries)
+	}
+}
+
+func TestSearchPostalCodesRecordsQueryPlans(t *testing.T) {
+	openTestDatabase(t)
+	ctx, info := WithDebug(context.Background(), true)
+	if _, err := SearchPostalCodes(ctx, utils.SearchParams{City: strPtr("Kraków"), Limit: 5}); err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+
+	if len(info.Queries) == 0 {
+		t.Fatal("expected recorded queries")
+	}
+	for _, query := range info.Queries {
+		if len(query.Plan) == 0 {
+			t.Errorf("expected a query plan for %s", query.SQL)
+		}
+	}
+}
+
+// TestServicesAgainstBackends runs the same searches against every backend. PostgreSQL needs a
+// postal_codes table loaded from the SQLite database and its DSN in POSTGRES_TEST_DSN.
+func TestServicesAgainstBackends(t *testing.T) {
+	backends := []struct {
+		name, driver, dsn string
+	}{
+		{"sqlite", database.DriverSQLite, testDBPath},
+		{"postgres", database.DriverPostgres, os.Getenv("POSTGRES_TEST_DSN")},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			if backend.driver == database.DriverSQLite {
+				openTestDatabase(t)
+			} else {
+				if backend.dsn == "" {
+					t.Skip("POSTGRES_TEST_DSN not set")
+				}
+				if err := database.InitializeWithDSN(backend.driver, backend.dsn); err != nil {
+					t.Fatalf("failed to connect: %v", err)
+				}
+				t.Cleanup(func() { database.Close() })
+			}
+
+			ctx := context.Background()
+			exact, err := SearchPostalCodes(ctx, utils.SearchParams{City: strPtr("kraków"), Street: strPtr("Długa"), Limit: 10})
+			if err != nil {
+				t.Fatalf("exact search failed: %v", err)
+			}
+			if exact.SearchType != "exact" || exact.Count == 0 {
+				t.Errorf("expected case-insensitive exact results, got %s with %d results", exact.SearchType, exact.Count)
+			}
+
+			normalized, err := SearchPostalCodes(ctx, utils.SearchParams{City: strPtr("Krakow"), Street: strPtr("Dluga"), Limit: 10})
+			if err != nil {
+				t.Fatalf("normalized search failed: %v", err)
+			}
+			if normalized.SearchType != "polish_characters" || normalized.Count == 0 {
+				t.Errorf("expected normalized results, got %s with %d results", normalized.SearchType, normalized.Count)
+			}
+
+			filtered, err := SearchPostalCodes(ctx, utils.SearchParams{County: strPtr("Kraków"), Province: strPtr("MAłOPOLSKIE,śląskie"), Limit: 5})
+			if err != nil {
+				t.Fatalf("list filter search failed: %v", err)
+			}
+			if filtered.Count == 0 {
+				t.Error("expected results for a case-insensitive province list")
+			}
+
+			lookup, err := GetPostalCodeByCode(ctx, "31-146")
+			if err != nil || lookup == nil {
+				t.Fatalf("lookup failed: %v", err)
+			}
+
+			sample, err := SamplePostalCodes(ctx, 3)
+			if err != nil || sample.Count != 3 {
+				t.Fatalf("sample failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetRegion(t *testing.T) {
+	openTestDatabase(t)
+	response, err := GetRegion(context.Background(), "31")
+	if err != nil {
+		t.Fatalf("GetRegion failed: %v", err)
+	}
+	if response == nil || len(response.Cities) == 0 || response.Cities[0].City != "Kraków" {
+		t.Fatalf("expected Kraków to lead region 31, got %+v", response)
+	}
+	if response.PostalCodeCount < response.Cities[0].PostalCodeCount || response.RecordCount < response.PostalCodeCount {
+		t.Errorf("inconsistent counts: %+v", response)
+	}
+
+	missing, err := GetRegion(context.Background(), "79")
+	if err != nil {
+		t.Fatalf("GetRegion failed: %v", err)
+	}
+	if missing != nil {
+		t.Errorf("expected no region 79, got %+v", missing)
+	}
+}
+
+func TestGetMultiCodeCities(t *testing.T) {
+	openTestDatabase(t)
+	response, err := GetMultiCodeCities(context.Background(), nil, ListOptions{Limit: 5, Sort: "count", Descending: true})
+	if err != nil {
+		t.Fatalf("GetMultiCodeCities failed: %v", err)
+	}
+	if response.Count != 5 || response.Cities[0].City != "Warszawa" {
+		t.Fatalf("expected Warszawa to have the most postal codes, got %+v", response.Cities)
+	}
+	for i, city := range response.Cities {
+		if city.PostalCodeCount < 2 {
+			t.Errorf("%s has only %d postal code", city.City, city.PostalCodeCount)
+		}
+		if i > 0 && city.PostalCodeCount > response.Cities[i-1].PostalCodeCount {
+			t.Errorf("cities are not ordered by postal code count: %+v", response.Cities)
+		}
+	}
+
+	province := "opolskie"
+	scoped, err := GetMultiCodeCities(context.Background(), &province, ListOptions{Limit: 100, Sort: "count", Descending: true})
+	if err != nil {
+		t.Fatalf("GetMultiCodeCities failed: %v", err)
+	}
+	if scoped.Total == 0 || scoped.Total >= response.Total {
+		t.Errorf("expected a smaller non-empty list for %s, got %d of %d", province, scoped.Total, response.Total)
+	}
+	for _, city := range scoped.Cities {
+		if city.Province != province {
+			t.Errorf("unexpected province %s for %s", city.Province, city.City)
+		}
+	}
+}
+
+func TestGetDetailedStreets(t *testing.T) {
+	openTestDatabase(t)
+	city := strPtr("Kraków")
+	response, err := GetDetailedStreets(context.Background(), city, nil, nil, nil, strPtr("Floriańska"), nil, ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetDetailedStreets failed: %v", err)
+	}
+	if response.Count == 0 {
+		t.Fatal("expected streets starting with Floriańska in Kraków")
+	}
+	for _, street := range response.Streets {
+		if len(street.PostalCodes) == 0 {
+			t.Errorf("%s has no postal codes", street.Street)
+		}
+		if !slices.IsSorted(street.PostalCodes) || len(slices.Compact(slices.Clone(street.PostalCodes))) != len(street.PostalCodes) {
+			t.Errorf("%s postal codes are not sorted and distinct: %v", street.Street, street.PostalCodes)
+		}
+	}
+
+	streets, err := GetStreets(context.Background(), city, nil, nil, nil, nil, nil, ListOptions{Limit: 1000})
+	if err != nil {
+		t.Fatalf("GetStreets failed: %v", err)
+	}
+	detailed, err := GetDetailedStreets(context.Background(), city, nil, nil, nil, nil, nil, ListOptions{Limit: 1000})
+	if err != nil {
+		t.Fatalf("GetDetailedStreets failed: %v", err)
+	}
+	if detailed.Total != streets.Total || detailed.Count != streets.Count {
+		t.Fatalf("expected the same streets as GetStreets, got %d/%d vs %d/%d", detailed.Count, detailed.Total, streets.Count, streets.Total)
+	}
+	for i, street := range detailed.Streets {
+		if street.Street != streets.Streets[i] {
+			t.Fatalf("street %d: expected %s, got %s", i, streets.Streets[i], street.Street)
+		}
+	}
+}
+
+func TestGetStreetsIncludePostalCodes(t *testing.T) {
+	openTestDatabase(t)
+	city := strPtr("Kraków")
+	plain, err := GetStreets(context.Background(), city, nil, nil, nil, nil, nil, ListOptions{Limit: 50})
+	if err != nil {
+		t.Fatalf("GetStreets failed: %v", err)
+	}
+	withCodes, err := GetStreets(context.Background(), city, nil, nil, nil, nil, nil, ListOptions{Limit: 50, IncludePostalCodes: true})
+	if err != nil {
+		t.Fatalf("GetStreets failed: %v", err)
+	}
+
+	var plainBody struct {
+		Streets []string `json:"streets"`
+	}
+	payload, _ := json.Marshal(plain)
+	if err := json.Unmarshal(payload, &plainBody); err != nil || len(plainBody.Streets) != 50 {
+		t.Fatalf("expected 50 street names by default, got %s", payload)
+	}
+
+	var detailedBody struct {
+		Streets []StreetPostalCodes `json:"streets"`
+	}
+	payload, _ = json.Marshal(withCodes)
+	if err := json.Unmarshal(payload, &detailedBody); err != nil {
+		t.Fatalf("expected street objects, got %s", payload)
+	}
+	for i, street := range detailedBody.Streets {
+		if street.Street != plainBody.Streets[i] || len(street.PostalCodes) == 0 {
+			t.Errorf("street %d: expected %s with postal codes, got %+v", i, plainBody.Streets[i], street)
+		}
+	}
+}
+
+func TestGetStreetsFilteredByPostalCode(t *testing.T) {
+	openTestDatabase(t)
+	response, err := GetStreets(context.Background(), nil, nil, nil, nil, nil, strPtr("31-146"), ListOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetStreets failed: %v", err)
+	}
+	if !slices.Contains(response.Streets, "Długa") {
+		t.Errorf("expected Długa under 31-146, got %v", response.Streets)
+	}
+
+	all, err := GetStreets(context.Background(), strPtr("Kraków"), nil, nil, nil, nil, nil, ListOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetStreets failed: %v", err)
+	}
+	if response.Total >= all.Total {
+		t.Errorf("expected the postal code to narrow the streets, got %d of %d", response.Total, all.Total)
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	openTestDatabase(t)
+	tests := []struct {
+		name                      string
+		city, street, houseNumber string
+		valid, exact              bool
+		postalCode                string
+	}{
+		{"exact address", "Kraków", "Długa", "4", true, true, "31-146"},
+		{"odd side", "Kraków", "Długa", "3", true, true, "31-147"},
+		{"without Polish characters", "Krakow", "Dluga", "3", true, false, "31-147"},
+		{"street only", "Kraków", "Długa", "", true, true, "31-146"},
+		{"city prefix is not an address", "Krak", "Długa", "", false, false, ""},
+		{"unknown street", "Kraków", "Nieistniejąca", "", false, false, ""},
+		{"stored street prefix is ignored", "Wrocław", "Muzealny", "", true, true, "50-035"},
+		{"typed street prefix is ignored", "Wroclaw", "ul. Muzealny", "", true, false, "50-035"},
+		{"single letter city is not an address", "W", "", "", false, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation, err := ValidateAddress(context.Background(), tt.city, tt.street, tt.houseNumber)
+			if err != nil {
+				t.Fatalf("ValidateAddress failed: %v", err)
+			}
+			if validation.Valid != tt.valid || validation.Exact != tt.exact || validation.PostalCode != tt.postalCode {
+				t.Errorf("got %+v, want valid=%t exact=%t postal_code=%q", validation, tt.valid, tt.exact, tt.postalCode)
+			}
+		})
+	}
+}
+
+func TestGetStreetHouseNumbering(t *testing.T) {
+	openTestDatabase(t)
+	numbered, err := GetStreetHouseNumbering(context.Background(), "Kraków", "Długa")
+	if err != nil {
+		t.Fatalf("GetStreetHouseNumbering failed: %v", err)
+	}
+	if numbered == nil || !numbered.HasHouseNumbers || numbered.NumberedRecords != numbered.Records {
+		t.Errorf("expected every Długa record in Kraków to list house numbers, got %+v", numbered)
+	}
+
+	unnumbered, err := GetStreetHouseNumbering(context.Background(), "Babienica", "Główna")
+	if err != nil {
+		t.Fatalf("GetStreetHouseNumbering failed: %v", err)
+	}
+	if unnumbered == nil || unnumbered.HasHouseNumbers || unnumbered.Records == 0 {
+		t.Errorf("expected Główna in Babienica to have records without house numbers, got %+v", unnumbered)
+	}
+
+	for _, street := range []string{"ul. Floriańska", "FLORIAŃSKA", "florianska"} {
+		spelled, err := GetStreetHouseNumbering(context.Background(), "Kraków", street)
+		if err != nil {
+			t.Fatalf("GetStreetHouseNumbering failed: %v", err)
+		}
+		if spelled == nil || spelled.Records == 0 {
+			t.Errorf("expected %q to find Floriańska in Kraków, got %+v", street, spelled)
+		}
+	}
+
+	missing, err := GetStreetHouseNumbering(context.Background(), "Kraków", "Nieistniejąca")
+	if err != nil || missing != nil {
+		t.Errorf("expected no result for an unknown street, got %+v (%v)", missing, err)
+	}
+
+	detailed, err := GetDetailedStreets(context.Background(), strPtr("Babienica"), nil, nil, nil, strPtr("Główna"), nil, ListOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetDetailedStreets failed: %v", err)
+	}
+	if detailed.Count != 1 || detailed.Streets[0].HasHouseNumbers {
+		t.Errorf("expected Główna without house numbers in the detailed list, got %+v", detailed.Streets)
+	}
+}
+
+func TestGetDistinctValues(t *testing.T) {
+	openTestDatabase(t)
+	response, err := GetDistinctValues(context.Background(), "province", ListOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetDistinctValues failed: %v", err)
+	}
+	if response.Total != 16 || response.Count != 16 {
+		t.Errorf("expected 16 provinces, got %d of %d", response.Count, response.Total)
+	}
+	if !slices.IsSorted(response.Values) || !slices.Contains(response.Values, "mazowieckie") {
+		t.Errorf("expected sorted provinces, got %v", response.Values)
+	}
+
+	if _, err := GetDistinctValues(context.Background(), "province; DROP TABLE postal_codes", ListOptions{Limit: 100}); err == nil {
+		t.Error("expected a column outside the whitelist to be rejected")
+	}
+}
+
+func TestLocationListsMatchContains(t *testing.T) {
+	openTestDatabase(t)
+	prefixed, err := GetCounties(context.Background(), nil, strPtr("szaws"), ListOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("GetCounties failed: %v", err)
+	}
+	if prefixed.Total != 0 {
+		t.Errorf("expected no county starting with szaws, got %v", prefixed.Counties)
+	}
+
+	contained, err := GetCounties(context.Background(), nil, strPtr("szaws"), ListOptions{Limit: 100, Contains: true})
+	if err != nil {
+		t.Fatalf("GetCounties failed: %v", err)
+	}
+	if !slices.Contains(contained.Counties, "warszawski zachodni") {
+		t.Errorf("expected warszawski zachodni to contain szaws, got %v", contained.Counties)
+	}
+
+	streets, err := GetStreets(context.Background(), strPtr("Kraków"), nil, nil, nil, strPtr("florian"), nil, ListOptions{Limit: 100, Contains: true})
+	if err != nil {
+		t.Fatalf("GetStreets failed: %v", err)
+	}
+	if !slices.Contains(streets.Streets, "Floriańska") || streets.Total < 2 {
+		t.Errorf("expected streets containing florian, got %v", streets.Streets)
+	}
+}
+
+func TestSearchCaseSensitive(t *testing.T) {
+	openTestDatabase(t)
+	tests := []struct {
+		name         string
+		city, street string
+		found        bool
+	}{
+		{"stored case", "Warszawa", "Marszałkowska", true},
+		{"lowercase city", "warszawa", "Marszałkowska", false},
+		{"lowercase street", "Warszawa", "marszałkowska", false},
+		{"lowercase without Polish characters", "warszawa", "marszalkowska", false},
+		{"stored case without Polish characters", "Warszawa", "Marszalkowska", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := utils.SearchParams{City: strPtr(tt.city), Street: strPtr(tt.street), Limit: 5, CaseSensitive: true, SkipFallback: true}
+			response, err := SearchPostalCodes(context.Background(), params)
+			if err != nil {
+				t.Fatalf("SearchPostalCodes failed: %v", err)
+			}
+			if found := response.Count > 0; found != tt.found {
+				t.Errorf("expected found=%t, got %d results", tt.found, response.Count)
+			}
+		})
+	}
+}
+
+func TestSearchExpandsStreetAbbreviations(t *testing.T) {
+	openTestDatabase(t)
+	tests := []struct {
+		city, street, stored string
+		exact                bool
+	}{
+		{"Wrocław", "Aleja Akacjowa", "al. Akacjowa", true},
+		{"Chorzów", "Plac Adama Mickiewicza", "pl. Adama Mickiewicza", true},
+		{"Gdynia", "pl. Świętego Andrzeja", "Plac Św. Andrzeja", true},
+		{"Kielce", "Św. Wojciecha", "pl. Świętego Wojciecha", false},
+		{"Police", "sw. Anny", "Świętej Anny", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.street, func(t *testing.T) {
+			params := utils.SearchParams{City: strPtr(tt.city), Street: strPtr(tt.street), Limit: 5, Exact: tt.exact, SkipFallback: true}
+			response, err := SearchPostalCodes(context.Background(), params)
+			if err != nil {
+				t.Fatalf("SearchPostalCodes failed: %v", err)
+			}
+			if response.Count == 0 || *response.Results[0].Street != tt.stored {
+				t.Errorf("expected %q to find %q, got %+v", tt.street, tt.stored, response.Results)
+			}
+		})
+	}
+}
+
+func TestSearchRanksExactCityBeforeTheLimit(t *testing.T) {
+	openTestDatabase(t)
+	params := utils.SearchParams{City: strPtr("Nowa Wieś"), Limit: 3}
+	response, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	for _, result := range response.Results {
+		if result.City != "Nowa Wieś" {
+			t.Errorf("expected only the exact city within the limit, got %s (%s)", result.City, result.PostalCode)
+		}
+	}
+
+	// Streaming applies the same order
+	var streamed []string
+	_, err = StreamSearch(context.Background(), params, func(_ string, row database.PostalCode) error {
+		streamed = append(streamed, row.PostalCode)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamSearch failed: %v", err)
+	}
+	for i, result := range response.Results {
+		if i >= len(streamed) || streamed[i] != result.PostalCode {
+			t.Fatalf("expected streamed rows in the order of the JSON results, got %v", streamed)
+		}
+	}
+}
+
+func TestGetStreetsPerCity(t *testing.T) {
+	openTestDatabase(t)
+	province := strPtr("małopolskie")
+	response, err := GetStreetsPerCity(context.Background(), province, ListOptions{Limit: 100, Sort: "count", Descending: true})
+	if err != nil {
+		t.Fatalf("GetStreetsPerCity failed: %v", err)
+	}
+	if len(response.Cities) == 0 || response.Cities[0].City != "Kraków" {
+		t.Fatalf("expected Kraków to have the most streets, got %+v", response.Cities)
+	}
+	for i := 1; i < len(response.Cities); i++ {
+		if response.Cities[i].StreetCount > response.Cities[i-1].StreetCount {
+			t.Errorf("expected street counts in descending order, got %+v", response.Cities)
+			break
+		}
+	}
+
+	streets, err := GetStreets(context.Background(), strPtr("Kraków"), province, nil, nil, nil, nil, ListOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("GetStreets failed: %v", err)
+	}
+	if response.Cities[0].StreetCount != streets.Total {
+		t.Errorf("expected %d streets in Kraków as listed by GetStreets, got %d", streets.Total, response.Cities[0].StreetCount)
+	}
+}
+
+func TestSuggestRanksByMatchQualityThenType(t *testing.T) {
+	openTestDatabase(t)
+	response, err := Suggest(context.Background(), "kraków", 5)
+	if err != nil {
+		t.Fatalf("Suggest failed: %v", err)
+	}
+	if response.Count != 5 || len(response.Suggestions) != 5 {
+		t.Fatalf("expected 5 suggestions, got %+v", response.Suggestions)
+	}
+	first := response.Suggestions[0]
+	if first.Type != SuggestionCounty || first.Value != "Kraków" || first.MatchQuality != MatchQualityExact {
+		t.Errorf("expected the exact county first, got %+v", first)
+	}
+	for i := 1; i < len(response.Suggestions); i++ {
+		if matchQualityRank[response.Suggestions[i].MatchQuality] > matchQualityRank[response.Suggestions[i-1].MatchQuality] {
+			t.Errorf("expected suggestions ranked by match quality, got %+v", response.Suggestions)
+			break
+		}
+	}
+
+	normalized, err := Suggest(context.Background(), "Malopol", 3)
+	if err != nil {
+		t.Fatalf("Suggest failed: %v", err)
+	}
+	if len(normalized.Suggestions) == 0 || normalized.Suggestions[0].Value != "małopolskie" {
+		t.Errorf("expected małopolskie without Polish characters, got %+v", normalized.Suggestions)
+	}
+}
+
+func TestCountPostalCodesAppliesSide(t *testing.T) {
+	openTestDatabase(t)
+	params := utils.SearchParams{City: strPtr("Kraków"), Street: strPtr("Długa"), Limit: 1000}
+	all, err := CountPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CountPostalCodes failed: %v", err)
+	}
+
+	for _, side := range []string{"even", "odd"} {
+		params.Side = side
+		count, err := CountPostalCodes(context.Background(), params)
+		if err != nil {
+			t.Fatalf("CountPostalCodes with side %s failed: %v", side, err)
+		}
+		search, err := SearchPostalCodes(context.Background(), params)
+		if err != nil {
+			t.Fatalf("SearchPostalCodes with side %s failed: %v", side, err)
+		}
+		if count.Count != len(search.Results) {
+			t.Errorf("side %s: count_only returned %d, search returned %d rows", side, count.Count, len(search.Results))
+		}
+		if count.Count == 0 || count.Count >= all.Count {
+			t.Errorf("side %s: expected a count between 0 and %d, got %d", side, all.Count, count.Count)
+		}
+	}
+}
+
+func TestGetCountiesAndMunicipalitiesFilterInSQL(t *testing.T) {
+	openTestDatabase(t)
+	ClearCaches()
+	t.Cleanup(ClearCaches)
+
+	all, err := GetMunicipalities(context.Background(), nil, nil, nil, ListOptions{Limit: 10000})
+	if err != nil {
+		t.Fatalf("GetMunicipalities failed: %v", err)
+	}
+	for _, filter := range []struct {
+		prefix   string
+		contains bool
+	}{{"lodz", false}, {"Łó", false}, {"WOLA", true}, {"ząb", true}} {
+		var want []string
+		for _, name := range all.Municipalities {
+			if matchesNameFilter(name, filter.prefix, filter.contains) {
+				want = append(want, name)
+			}
+		}
+		got, err := GetMunicipalities(context.Background(), nil, nil, strPtr(filter.prefix), ListOptions{Limit: 10000, Contains: filter.contains})
+		if err != nil {
+			t.Fatalf("GetMunicipalities failed: %v", err)
+		}
+		if len(want) == 0 || !slices.Equal(got.Municipalities, want) || got.Total != len(want) {
+			t.Errorf("filter %+v: got %d of %d, want %v", filter, len(got.Municipalities), got.Total, want)
+		}
+	}
+
+	page, err := GetCounties(context.Background(), nil, strPtr("lodz"), ListOptions{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("GetCounties failed: %v", err)
+	}
+	if page.Total < 2 || page.Count != 1 {
+		t.Errorf("expected the second of several counties, got %+v", page)
+	}
+}
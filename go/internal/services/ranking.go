@@ -0,0 +1,24 @@
+package services
+
+import (
+	"fmt"
+
+	"postal-api/internal/utils"
+)
+
+// searchOrderBy builds the ORDER BY clause of a search query and its arguments. Without an explicit sort, a
+// search for a city ranks in SQL so the limit keeps the best rows: rows whose city equals the input come first,
+// larger cities lead among the rest, and the postal code and id keep the order stable. Ranking before the limit
+// means the exact city is returned even when many prefix matches have lower postal codes.
+func searchOrderBy(params utils.SearchParams, cityCol string) (string, []interface{}) {
+	if params.Sort != "" || params.City == nil || *params.City == "" {
+		return buildOrderByClause(params.Sort), nil
+	}
+
+	collate := " COLLATE NOCASE"
+	if params.CaseSensitive {
+		collate = ""
+	}
+	orderBy := fmt.Sprintf(" ORDER BY CASE WHEN %s = ?%s THEN 0 ELSE 1 END, COALESCE(population, 0) DESC, postal_code ASC, id", cityCol, collate)
+	return orderBy, []interface{}{*params.City}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"postal-api/internal/cache"
+	"postal-api/internal/config"
+	"postal-api/internal/database"
+)
+
+// RegionCity is a city with postal codes in a region
+type RegionCity struct {
+	City            string `json:"city"`
+	County          string `json:"county"`
+	Province        string `json:"province"`
+	PostalCodeCount int    `json:"postal_code_count"`
+}
+
+// RegionResponse describes the postal district identified by the first two digits of a postal code
+type RegionResponse struct {
+	Prefix          string          `json:"prefix"`
+	PostalCodeCount int             `json:"postal_code_count"`
+	CityCount       int             `json:"city_count"`
+	RecordCount     int             `json:"record_count"`
+	Provinces       []ProvinceStats `json:"provinces"`
+	Cities          []RegionCity    `json:"cities"`
+}
+
+// regionCache holds region summaries by prefix
+var regionCache = cache.New[*RegionResponse](config.LocationCacheTTL())
+
+// regionCondition selects the postal codes starting with a two-digit prefix. It compares the first two
+// characters instead of a range on postal_code, whose bounds would depend on each backend's collation.
+const regionCondition = "substr(postal_code, 1, 2) = ?"
+
+// GetRegion summarizes the provinces and cities whose postal codes start with prefix, largest first.
+// It returns nil when no postal code has the prefix.
+func GetRegion(ctx context.Context, prefix string) (*RegionResponse, error) {
+	if cached, ok := regionCache.Get(prefix); ok {
+		return cached, nil
+	}
+
+	db := database.GetDB()
+	args := []interface{}{prefix}
+	response := &RegionResponse{Prefix: prefix, Provinces: []ProvinceStats{}, Cities: []RegionCity{}}
+
+	totalQuery := "SELECT COUNT(*), COUNT(DISTINCT postal_code), COUNT(DISTINCT city_clean) FROM postal_codes WHERE " + regionCondition
+	start := time.Now()
+	if err := db.QueryRowContext(ctx, totalQuery, args...).Scan(&response.RecordCount, &response.PostalCodeCount, &response.CityCount); err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	logSlowQuery(start, totalQuery, args)
+	if response.RecordCount == 0 {
+		return nil, nil
+	}
+
+	provinceQuery := `SELECT province, COUNT(DISTINCT postal_code), COUNT(DISTINCT city_clean) FROM postal_codes
+		WHERE ` + regionCondition + ` AND province IS NOT NULL
+		GROUP BY province ORDER BY COUNT(DISTINCT postal_code) DESC, province`
+	if err := scanRegionRows(ctx, provinceQuery, args, func(scan func(...interface{}) error) error {
+		var stats ProvinceStats
+		if err := scan(&stats.Province, &stats.PostalCodeCount, &stats.CityCount); err != nil {
+			return err
+		}
+		response.Provinces = append(response.Provinces, stats)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	cityQuery := `SELECT city_clean, county, province, COUNT(DISTINCT postal_code) FROM postal_codes
+		WHERE ` + regionCondition + ` AND city_clean IS NOT NULL AND county IS NOT NULL AND province IS NOT NULL
+		GROUP BY city_clean, county, province ORDER BY COUNT(DISTINCT postal_code) DESC, city_clean`
+	if err := scanRegionRows(ctx, cityQuery, args, func(scan func(...interface{}) error) error {
+		var city RegionCity
+		if err := scan(&city.City, &city.County, &city.Province, &city.PostalCodeCount); err != nil {
+			return err
+		}
+		response.Cities = append(response.Cities, city)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	regionCache.Set(prefix, response)
+	return response, nil
+}
+
+// scanRegionRows runs a query and passes each row's Scan to handle
+func scanRegionRows(ctx context.Context, query string, args []interface{}, handle func(scan func(...interface{}) error) error) error {
+	defer logSlowQuery(time.Now(), query, args)
+	rows, err := database.GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := handle(rows.Scan); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"postal-api/internal/database"
+	"postal-api/internal/utils"
+)
+
+// PostalRepository is the data access behind searches, counts, postal code lookups and the province, county
+// and municipality lists. The SQL implementation runs on the shared database connection; tests replace it with
+// fakes through SetRepository. Geospatial queries, city and street lists, statistics and admin tasks still use
+// database.GetDB directly.
+type PostalRepository interface {
+	// Search returns rows matching the search parameters, comparing the normalized columns when useNormalized is set.
+	// The tier labels the query in debug output.
+	Search(ctx context.Context, tier string, params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error)
+	// SearchEach runs the same query as Search but passes each row to fn as it is read.
+	// Returning ErrStopSearch from fn ends the iteration without an error.
+	SearchEach(ctx context.Context, tier string, params utils.SearchParams, useNormalized bool, fn func(database.PostalCode) error) error
+	// GetByCode returns every record with the postal code
+	GetByCode(ctx context.Context, postalCode string) ([]database.PostalCode, error)
+	// ListProvinces returns all province names in alphabetical order
+	ListProvinces(ctx context.Context) ([]string, error)
+	// ProvinceExists reports whether any record lies in the province, ignoring case
+	ProvinceExists(ctx context.Context, province string) (bool, error)
+	// ProvincesOfCounty returns the provinces containing the county, ignoring case
+	ProvincesOfCounty(ctx context.Context, county string) ([]string, error)
+	// ListCitiesByPopulation returns all distinct city names, largest first
+	ListCitiesByPopulation(ctx context.Context) ([]string, error)
+	// Count returns how many rows Search would return, applying the house number and side filters
+	Count(ctx context.Context, params utils.SearchParams, useNormalized bool) (int, error)
+	// ListCounties returns one page of the counties matching the filters and the number of matches
+	ListCounties(ctx context.Context, province, prefix *string, opts ListOptions) ([]string, int, error)
+	// ListMunicipalities returns one page of the municipalities matching the filters and the number of matches
+	ListMunicipalities(ctx context.Context, province, county, prefix *string, opts ListOptions) ([]string, int, error)
+}
+
+// ErrStopSearch is returned by a SearchEach callback to stop reading rows
+var ErrStopSearch = errors.New("stop search")
+
+// repository is the PostalRepository used by the services
+var repository PostalRepository = sqlRepository{}
+
+// SetRepository replaces the repository used by the services and returns the previous one
+func SetRepository(r PostalRepository) PostalRepository {
+	previous := repository
+	repository = r
+	return previous
+}
+
+// sqlRepository implements PostalRepository with SQL on database.GetDB
+type sqlRepository struct{}
+
+func (r sqlRepository) Search(ctx context.Context, tier string, params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+	var results []database.PostalCode
+	err := r.SearchEach(ctx, tier, params, useNormalized, func(row database.PostalCode) error {
+		results = append(results, row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (sqlRepository) SearchEach(ctx context.Context, tier string, params utils.SearchParams, useNormalized bool, fn func(database.PostalCode) error) error {
+	query, args := buildSearchQuery(params, useNormalized)
+	defer logSlowQuery(time.Now(), query, args)
+	rows, err := database.GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		row, err := database.ScanPostalCode(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		count++
+		if err := fn(row); errors.Is(err, ErrStopSearch) {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return recordQuery(ctx, tier, query, args, count)
+}
+
+func (sqlRepository) GetByCode(ctx context.Context, postalCode string) ([]database.PostalCode, error) {
+	query := "SELECT " + database.PostalCodeColumns() + " FROM postal_codes WHERE postal_code = ? ORDER BY id"
+	defer logSlowQuery(time.Now(), query, []interface{}{postalCode})
+	rows, err := database.GetDB().QueryContext(ctx, query, postalCode)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	results, err := database.ScanPostalCodes(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+	return results, nil
+}
+
+func (sqlRepository) ListProvinces(ctx context.Context) ([]string, error) {
+	return queryStrings(ctx, "SELECT DISTINCT province FROM postal_codes WHERE province IS NOT NULL ORDER BY province")
+}
+
+func (sqlRepository) ProvinceExists(ctx context.Context, province string) (bool, error) {
+	query := "SELECT 1 FROM postal_codes WHERE province = ? COLLATE NOCASE LIMIT 1"
+	defer logSlowQuery(time.Now(), query, []interface{}{province})
+	var exists int
+	err := database.GetDB().QueryRowContext(ctx, query, province).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("database query failed: %w", err)
+	}
+	return true, nil
+}
+
+func (sqlRepository) ProvincesOfCounty(ctx context.Context, county string) ([]string, error) {
+	return queryStrings(ctx, "SELECT DISTINCT province FROM postal_codes WHERE county = ? COLLATE NOCASE ORDER BY province", county)
+}
+
+func (sqlRepository) ListCitiesByPopulation(ctx context.Context) ([]string, error) {
+	return queryStrings(ctx, "SELECT city_clean FROM postal_codes WHERE city_clean IS NOT NULL GROUP BY city_clean ORDER BY MAX(population) DESC, city_clean")
+}
+
+func (sqlRepository) Count(ctx context.Context, params utils.SearchParams, useNormalized bool) (int, error) {
+	db := database.GetDB()
+	conditions, args := buildSearchConditions(params, useNormalized)
+
+	houseNumber := ""
+	if params.HouseNumber != nil {
+		houseNumber = *params.HouseNumber
+	}
+	if houseNumber == "" && params.Side == "" {
+		var count int
+		query := "SELECT COUNT(*) FROM postal_codes WHERE 1=1" + conditions
+		defer logSlowQuery(time.Now(), query, args)
+		if err := db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+			return 0, fmt.Errorf("count query failed: %w", err)
+		}
+		return count, nil
+	}
+
+	// A house number on the other side matches nothing, as in filterBySide
+	if houseNumber != "" && params.Side != "" && !utils.IsHouseNumberOnSide(houseNumber, params.Side) {
+		return 0, nil
+	}
+
+	// House number ranges and sides can only be evaluated in Go, so only the range column is fetched
+	query := "SELECT COALESCE(house_numbers, '') FROM postal_codes WHERE 1=1" + conditions
+	if houseNumber != "" && !params.AssumeAllWhenEmpty {
+		query = "SELECT house_numbers FROM postal_codes WHERE house_numbers IS NOT NULL AND house_numbers != ''" + conditions
+	}
+	defer logSlowQuery(time.Now(), query, args)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("count query failed: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var houseNumbers string
+		if err := rows.Scan(&houseNumbers); err != nil {
+			return 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		switch {
+		case houseNumbers == "":
+			count++
+		case houseNumber != "":
+			if utils.IsHouseNumberInRange(houseNumber, houseNumbers) {
+				count++
+			}
+		case utils.RangeIncludesSide(houseNumbers, params.Side):
+			count++
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return count, nil
+}
+
+func (sqlRepository) ListCounties(ctx context.Context, province, prefix *string, opts ListOptions) ([]string, int, error) {
+	from := "FROM postal_codes WHERE county IS NOT NULL"
+	var args []interface{}
+	from, args = appendListFilter(from, args, "province", province)
+	from, args = filterDistinctNames(from, args, "county", prefix, opts.Contains)
+	return queryLocationPage(ctx, "county", from, args, "county", opts)
+}
+
+func (sqlRepository) ListMunicipalities(ctx context.Context, province, county, prefix *string, opts ListOptions) ([]string, int, error) {
+	from := "FROM postal_codes WHERE municipality IS NOT NULL"
+	var args []interface{}
+	from, args = appendListFilter(from, args, "province", province)
+	from, args = appendListFilter(from, args, "county", county)
+	from, args = filterDistinctNames(from, args, "municipality", prefix, opts.Contains)
+	return queryLocationPage(ctx, "municipality", from, args, "municipality", opts)
+}
+
+// queryStrings runs a query selecting a single text column and returns its values
+func queryStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	defer logSlowQuery(time.Now(), query, args)
+	rows, err := database.GetDB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,475 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"postal-api/internal/database"
+	"postal-api/internal/i18n"
+	"postal-api/internal/utils"
+)
+
+// fakeRepository serves canned rows and records the search tiers it was asked for
+type fakeRepository struct {
+	search          func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error)
+	provinces       []string
+	countyProvinces map[string][]string
+	// citiesByPopulation lists city names, largest first
+	citiesByPopulation []string
+	counties           []string
+	municipalities     []string
+	tiers              []string
+}
+
+func (f *fakeRepository) Search(ctx context.Context, tier string, params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+	f.tiers = append(f.tiers, tier)
+	return f.search(params, useNormalized)
+}
+
+func (f *fakeRepository) SearchEach(ctx context.Context, tier string, params utils.SearchParams, useNormalized bool, fn func(database.PostalCode) error) error {
+	rows, err := f.Search(ctx, tier, params, useNormalized)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := fn(row); errors.Is(err, ErrStopSearch) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeRepository) GetByCode(ctx context.Context, postalCode string) ([]database.PostalCode, error) {
+	return nil, nil
+}
+
+func (f *fakeRepository) ListProvinces(ctx context.Context) ([]string, error) {
+	return f.provinces, nil
+}
+
+func (f *fakeRepository) ProvinceExists(ctx context.Context, province string) (bool, error) {
+	return slices.ContainsFunc(f.provinces, func(p string) bool { return strings.EqualFold(p, province) }), nil
+}
+
+func (f *fakeRepository) ProvincesOfCounty(ctx context.Context, county string) ([]string, error) {
+	return f.countyProvinces[strings.ToLower(county)], nil
+}
+
+func (f *fakeRepository) ListCitiesByPopulation(ctx context.Context) ([]string, error) {
+	return f.citiesByPopulation, nil
+}
+
+func (f *fakeRepository) Count(ctx context.Context, params utils.SearchParams, useNormalized bool) (int, error) {
+	rows, err := f.search(params, useNormalized)
+	return len(rows), err
+}
+
+func (f *fakeRepository) ListCounties(ctx context.Context, province, prefix *string, opts ListOptions) ([]string, int, error) {
+	matches := filterNames(f.counties, prefix, opts)
+	return paginate(matches, opts), len(matches), nil
+}
+
+func (f *fakeRepository) ListMunicipalities(ctx context.Context, province, county, prefix *string, opts ListOptions) ([]string, int, error) {
+	matches := filterNames(f.municipalities, prefix, opts)
+	return paginate(matches, opts), len(matches), nil
+}
+
+// filterNames keeps the names matching the prefix filter of a location list
+func filterNames(names []string, prefix *string, opts ListOptions) []string {
+	if prefix == nil || *prefix == "" {
+		return names
+	}
+	var matches []string
+	for _, name := range names {
+		if matchesNameFilter(name, *prefix, opts.Contains) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+// useFakeRepository installs a fake repository for the duration of the test
+func useFakeRepository(t *testing.T, fake *fakeRepository) {
+	previous := SetRepository(fake)
+	t.Cleanup(func() { SetRepository(previous) })
+}
+
+// marszalkowska is an odd-side record used by the tier tests
+var marszalkowska = database.PostalCode{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("1-21(n)"), Province: "mazowieckie"}
+
+func TestSearchTiersStopAtExactMatch(t *testing.T) {
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		return []database.PostalCode{marszalkowska}, nil
+	}}
+	useFakeRepository(t, fake)
+
+	response, err := SearchPostalCodes(context.Background(), utils.SearchParams{City: strPtr("Warszawa"), Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if response.SearchType != "exact" || response.Count != 1 || response.FallbackLevel != FallbackLevelExact {
+		t.Errorf("expected one exact result, got %s with %d at level %d", response.SearchType, response.Count, response.FallbackLevel)
+	}
+	if response.NormalizedParams != nil {
+		t.Errorf("expected no normalized params for an exact match, got %+v", response.NormalizedParams)
+	}
+	if !slices.Equal(fake.tiers, []string{"exact"}) {
+		t.Errorf("expected only the exact tier to run, got %v", fake.tiers)
+	}
+}
+
+func TestSearchTiersFallBackToNormalizedColumns(t *testing.T) {
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		if !useNormalized {
+			return nil, nil
+		}
+		if *params.Street != "Marszalkowska" {
+			t.Errorf("expected the normalized street, got %q", *params.Street)
+		}
+		return []database.PostalCode{marszalkowska}, nil
+	}}
+	useFakeRepository(t, fake)
+
+	response, err := SearchPostalCodes(context.Background(), utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska"), Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if response.SearchType != "polish_characters" || !response.PolishNormalizationUsed || response.FallbackLevel != FallbackLevelPolish {
+		t.Errorf("expected a polish_characters result, got %+v", response)
+	}
+	if normalized := response.NormalizedParams; normalized == nil || *normalized.Street != "Marszalkowska" || normalized.HouseNumber != nil {
+		t.Errorf("expected the normalized street to be echoed, got %+v", normalized)
+	}
+	if !slices.Equal(fake.tiers, []string{"exact", "polish_characters"}) {
+		t.Errorf("unexpected tiers %v", fake.tiers)
+	}
+}
+
+func TestSearchTiersSkipNormalization(t *testing.T) {
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		if useNormalized {
+			return []database.PostalCode{marszalkowska}, nil
+		}
+		return nil, nil
+	}}
+	useFakeRepository(t, fake)
+
+	params := utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska"), Limit: 10, SkipNormalization: true}
+	response, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if response.Count != 0 || response.PolishNormalizationUsed {
+		t.Errorf("expected no normalized results, got %+v", response)
+	}
+	if !slices.Equal(fake.tiers, []string{"exact", "fallback"}) {
+		t.Errorf("expected the normalization tiers to be skipped, got %v", fake.tiers)
+	}
+}
+
+func TestSearchTiersDropUnmatchedHouseNumber(t *testing.T) {
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		return []database.PostalCode{marszalkowska}, nil
+	}}
+	useFakeRepository(t, fake)
+
+	params := utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska"), HouseNumber: strPtr("2"), Limit: 10}
+	response, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if !response.FallbackUsed || response.Count != 1 || response.FallbackLevel != FallbackLevelHouseNumber {
+		t.Errorf("expected a house number fallback result, got %+v", response)
+	}
+	if !strings.Contains(response.Message, "House number '2' not found") {
+		t.Errorf("unexpected message %q", response.Message)
+	}
+	if !slices.Equal(fake.tiers, []string{"exact", "polish_characters", "fallback"}) {
+		t.Errorf("unexpected tiers %v", fake.tiers)
+	}
+}
+
+func TestSearchTiersReportPolishStreetFallback(t *testing.T) {
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		// Only the normalized city-only search finds the record
+		if !useNormalized || params.Street != nil {
+			return nil, nil
+		}
+		return []database.PostalCode{marszalkowska}, nil
+	}}
+	useFakeRepository(t, fake)
+
+	response, err := SearchPostalCodes(context.Background(), utils.SearchParams{City: strPtr("Łódź"), Street: strPtr("Nieznana"), Limit: 10})
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if response.FallbackLevel != FallbackLevelPolishStreet || !response.FallbackUsed {
+		t.Errorf("expected a Polish street fallback, got level %d", response.FallbackLevel)
+	}
+}
+
+func TestSearchTiersSkipFallback(t *testing.T) {
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		return []database.PostalCode{marszalkowska}, nil
+	}}
+	useFakeRepository(t, fake)
+
+	params := utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska"), HouseNumber: strPtr("2"), Limit: 10, SkipFallback: true}
+	response, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if response.FallbackUsed || response.Count != 0 || response.Message != "" {
+		t.Errorf("expected an empty strict result, got %+v", response)
+	}
+	if !slices.Equal(fake.tiers, []string{"exact", "polish_characters"}) {
+		t.Errorf("expected the fallback tiers to be skipped, got %v", fake.tiers)
+	}
+}
+
+func TestSearchTiersPropagateRepositoryErrors(t *testing.T) {
+	failure := errors.New("connection lost")
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		if useNormalized {
+			return nil, failure
+		}
+		return nil, nil
+	}}
+	useFakeRepository(t, fake)
+
+	_, err := SearchPostalCodes(context.Background(), utils.SearchParams{City: strPtr("Warszawa"), Limit: 10})
+	if !errors.Is(err, failure) {
+		t.Errorf("expected the repository error, got %v", err)
+	}
+}
+
+func TestExplainEmptyHierarchyWithFakeRepository(t *testing.T) {
+	useFakeRepository(t, &fakeRepository{
+		provinces:       []string{"mazowieckie", "małopolskie"},
+		countyProvinces: map[string][]string{"kraków": {"małopolskie"}},
+	})
+
+	message, err := explainEmptyHierarchy(context.Background(), strPtr("mazowieckie"), strPtr("Kraków"))
+	if err != nil {
+		t.Fatalf("explainEmptyHierarchy failed: %v", err)
+	}
+	if want := "County 'Kraków' does not belong to province 'mazowieckie'; it is in małopolskie."; message != want {
+		t.Errorf("got %q, want %q", message, want)
+	}
+}
+
+func TestSearchAssumeAllWhenEmpty(t *testing.T) {
+	wholeStreet := database.PostalCode{PostalCode: "00-001", City: "Warszawa", Street: strPtr("Marszałkowska"), Province: "mazowieckie"}
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		return []database.PostalCode{marszalkowska, wholeStreet}, nil
+	}}
+	useFakeRepository(t, fake)
+
+	params := utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska"), HouseNumber: strPtr("2"), Limit: 10}
+	strict, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if !strict.FallbackUsed {
+		t.Errorf("expected the strict search to fall back, got %+v", strict)
+	}
+
+	params.AssumeAllWhenEmpty = true
+	lenient, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if lenient.FallbackUsed || lenient.Count != 1 || lenient.Results[0].PostalCode != "00-001" {
+		t.Errorf("expected only the record without house numbers, got %+v", lenient)
+	}
+}
+
+func TestStreamSearchEmitsRowsUpToLimit(t *testing.T) {
+	rows := []database.PostalCode{marszalkowska, marszalkowska, marszalkowska}
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		if useNormalized {
+			return rows, nil
+		}
+		return nil, nil
+	}}
+	useFakeRepository(t, fake)
+
+	var emitted []string
+	searchType, err := StreamSearch(context.Background(), utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska"), HouseNumber: strPtr("5"), Limit: 2},
+		func(searchType string, row database.PostalCode) error {
+			emitted = append(emitted, searchType+" "+*row.MatchedRange)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("StreamSearch failed: %v", err)
+	}
+	if searchType != "polish_characters" {
+		t.Errorf("expected the polish_characters tier, got %s", searchType)
+	}
+	if !slices.Equal(emitted, []string{"polish_characters 1-21(n)", "polish_characters 1-21(n)"}) {
+		t.Errorf("unexpected rows %v", emitted)
+	}
+}
+
+func TestStreamSearchWithoutLimitSkipsFallback(t *testing.T) {
+	rows := []database.PostalCode{marszalkowska, marszalkowska, marszalkowska}
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		return rows, nil
+	}}
+	useFakeRepository(t, fake)
+
+	count := 0
+	emit := func(searchType string, row database.PostalCode) error {
+		count++
+		return nil
+	}
+	searchType, err := StreamSearch(context.Background(), utils.SearchParams{City: strPtr("Warszawa"), Limit: 0}, emit)
+	if err != nil {
+		t.Fatalf("StreamSearch failed: %v", err)
+	}
+	if searchType != "exact" || count != len(rows) {
+		t.Errorf("expected every exact row, got %s with %d", searchType, count)
+	}
+
+	// An unmatched house number would fall back to the whole street; unlimited exports stop instead
+	fake.tiers = nil
+	count = 0
+	params := utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska"), HouseNumber: strPtr("2"), Limit: 0}
+	searchType, err = StreamSearch(context.Background(), params, emit)
+	if err != nil {
+		t.Fatalf("StreamSearch failed: %v", err)
+	}
+	if searchType != "none" || count != 0 {
+		t.Errorf("expected no rows, got %s with %d", searchType, count)
+	}
+	if !slices.Equal(fake.tiers, []string{"exact", "polish_characters"}) {
+		t.Errorf("expected only the streamed tiers to run, got %v", fake.tiers)
+	}
+}
+
+func TestSearchQueryRanksExactCityFirst(t *testing.T) {
+	query, args := buildSearchQuery(utils.SearchParams{City: strPtr("Nowa Wieś"), Limit: 1}, false)
+	if !strings.Contains(query, " ORDER BY CASE WHEN city_clean = ? COLLATE NOCASE THEN 0 ELSE 1 END, COALESCE(population, 0) DESC") {
+		t.Errorf("expected the exact city and population to lead the ORDER BY, got %s", query)
+	}
+	if len(args) != 3 || args[1] != "Nowa Wieś" || args[2] != 1 {
+		t.Errorf("expected the city rank argument before the limit, got %v", args)
+	}
+
+	query, _ = buildSearchQuery(utils.SearchParams{City: strPtr("Nowa Wies"), Limit: 1}, true)
+	if !strings.Contains(query, "CASE WHEN city_normalized = ?") {
+		t.Errorf("expected the normalized tier to rank on city_normalized, got %s", query)
+	}
+
+	// An explicit sort or a search without a city keeps the plain column order
+	for _, params := range []utils.SearchParams{
+		{City: strPtr("Nowa Wieś"), Sort: "postal_code", Limit: 1},
+		{Street: strPtr("Floriańska"), Limit: 1},
+	} {
+		if query, _ := buildSearchQuery(params, false); !strings.Contains(query, " ORDER BY postal_code ASC, id LIMIT ?") {
+			t.Errorf("expected the postal code order, got %s", query)
+		}
+	}
+}
+
+func TestSearchTiersPhoneticCity(t *testing.T) {
+	record := database.PostalCode{PostalCode: "66-435", City: "Krzesznice", Province: "lubuskie"}
+	fake := &fakeRepository{
+		search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+			if params.City != nil && *params.City == record.City {
+				return []database.PostalCode{record}, nil
+			}
+			return nil, nil
+		},
+		citiesByPopulation: []string{"Warszawa", "Krzesznice"},
+	}
+	useFakeRepository(t, fake)
+	ClearCaches()
+	t.Cleanup(ClearCaches)
+
+	params := utils.SearchParams{City: strPtr("Kshesznyce"), Limit: 10}
+	response, err := SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if response.Count != 0 {
+		t.Errorf("expected no phonetic match without phonetic=true, got %+v", response)
+	}
+
+	params.Phonetic = true
+	response, err = SearchPostalCodes(context.Background(), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if response.SearchType != "phonetic" || response.FallbackLevel != FallbackLevelPhonetic || response.Count != 1 {
+		t.Fatalf("expected a phonetic result, got %+v", response)
+	}
+	if !strings.Contains(response.Message, "'Krzesznice'") {
+		t.Errorf("expected the matched city in the message, got %q", response.Message)
+	}
+
+	response, err = SearchPostalCodes(i18n.WithLanguage(context.Background(), i18n.Polish), params)
+	if err != nil {
+		t.Fatalf("SearchPostalCodes failed: %v", err)
+	}
+	if want := "Nie znaleziono miejscowości 'Kshesznyce', wyświetlono wyniki dla podobnie brzmiącej 'Krzesznice'."; response.Message != want {
+		t.Errorf("expected the Polish correction %q, got %q", want, response.Message)
+	}
+}
+
+func TestCountPostalCodesFallsBackToNormalizedColumns(t *testing.T) {
+	fake := &fakeRepository{search: func(params utils.SearchParams, useNormalized bool) ([]database.PostalCode, error) {
+		if !useNormalized {
+			return nil, nil
+		}
+		return []database.PostalCode{marszalkowska, marszalkowska}, nil
+	}}
+	useFakeRepository(t, fake)
+
+	response, err := CountPostalCodes(context.Background(), utils.SearchParams{City: strPtr("Warszawa"), Street: strPtr("Marszałkowska")})
+	if err != nil {
+		t.Fatalf("CountPostalCodes failed: %v", err)
+	}
+	if response.Count != 2 {
+		t.Errorf("expected the normalized count of 2, got %d", response.Count)
+	}
+
+	response, err = CountPostalCodes(context.Background(), utils.SearchParams{City: strPtr("Warszawa"), SkipNormalization: true})
+	if err != nil {
+		t.Fatalf("CountPostalCodes failed: %v", err)
+	}
+	if response.Count != 0 {
+		t.Errorf("expected no count without normalization, got %d", response.Count)
+	}
+}
+
+func TestGetCountiesAndMunicipalitiesWithFakeRepository(t *testing.T) {
+	useFakeRepository(t, &fakeRepository{
+		provinces:      []string{"małopolskie"},
+		counties:       []string{"krakowski", "Kraków", "nowotarski"},
+		municipalities: []string{"Kraków", "Krzeszowice", "Zabierzów"},
+	})
+	ClearCaches()
+	t.Cleanup(ClearCaches)
+
+	counties, err := GetCounties(context.Background(), nil, strPtr("krak"), ListOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("GetCounties failed: %v", err)
+	}
+	if counties.Total != 2 || !slices.Equal(counties.Counties, []string{"krakowski"}) {
+		t.Errorf("expected the first of two matching counties, got %+v", counties)
+	}
+
+	municipalities, err := GetMunicipalities(context.Background(), nil, nil, strPtr("szow"), ListOptions{Limit: 10, Contains: true})
+	if err != nil {
+		t.Fatalf("GetMunicipalities failed: %v", err)
+	}
+	if municipalities.Total != 1 || !slices.Equal(municipalities.Municipalities, []string{"Krzeszowice"}) {
+		t.Errorf("expected Krzeszowice, got %+v", municipalities)
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"slices"
+
+	"postal-api/internal/database"
+)
+
+// MergedPostalCode is a search result standing for every row that shares its postal code, street and city.
+// HouseNumbers lists the house_numbers ranges of all merged rows and replaces the single range in JSON.
+type MergedPostalCode struct {
+	database.PostalCode
+	HouseNumbers []string `json:"house_numbers"`
+}
+
+// dedupeResults collapses rows with the same postal code, street and city into one result, keeping the first
+// row's other fields and the order in which each combination first appeared
+func dedupeResults(results []database.PostalCode) []MergedPostalCode {
+	merged := []MergedPostalCode{}
+	index := make(map[string]int)
+	for _, row := range results {
+		street := ""
+		if row.Street != nil {
+			street = *row.Street
+		}
+		key := row.PostalCode + "\x00" + street + "\x00" + row.City
+
+		i, ok := index[key]
+		if !ok {
+			i = len(merged)
+			index[key] = i
+			merged = append(merged, MergedPostalCode{PostalCode: row, HouseNumbers: []string{}})
+		}
+		if row.HouseNumbers != nil && *row.HouseNumbers != "" && !slices.Contains(merged[i].HouseNumbers, *row.HouseNumbers) {
+			merged[i].HouseNumbers = append(merged[i].HouseNumbers, *row.HouseNumbers)
+		}
+	}
+	return merged
+}
+
+// GroupByPostalCode is the group_by value grouping search results by postal code
+const GroupByPostalCode = "postal_code"
+
+// PostalCodeGroup is a postal code with the streets of one city it covers
+type PostalCodeGroup struct {
+	PostalCode string   `json:"postal_code"`
+	City       string   `json:"city"`
+	Streets    []string `json:"streets"`
+}
+
+// groupByPostalCode groups rows by postal code and city, listing each street once in the order the rows came.
+// A code covering several places yields one group per city; rows without a street add none.
+func groupByPostalCode(results []database.PostalCode) []PostalCodeGroup {
+	groups := []PostalCodeGroup{}
+	index := make(map[string]int)
+	for _, row := range results {
+		key := row.PostalCode + "\x00" + row.City
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, PostalCodeGroup{PostalCode: row.PostalCode, City: row.City, Streets: []string{}})
+		}
+		if row.Street != nil && *row.Street != "" && !slices.Contains(groups[i].Streets, *row.Street) {
+			groups[i].Streets = append(groups[i].Streets, *row.Street)
+		}
+	}
+	return groups
+}
+
+// selectFields is PostalCode.SelectFields with the merged house number list
+func (m MergedPostalCode) selectFields(fields []string) map[string]interface{} {
+	selected := m.PostalCode.SelectFields(fields)
+	if slices.Contains(fields, "house_numbers") {
+		selected["house_numbers"] = m.HouseNumbers
+	}
+	return selected
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"postal-api/internal/database"
+)
+
+func TestDedupeResultsMergesHouseNumbers(t *testing.T) {
+	rows := []database.PostalCode{
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("1-21(n)")},
+		{PostalCode: "00-001", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("2-10(p)")},
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("2-20(p)")},
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("1-21(n)")},
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Nowy Świat")},
+	}
+
+	merged := dedupeResults(rows)
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 merged results, got %d", len(merged))
+	}
+	if merged[0].PostalCode.PostalCode != "00-624" || !slices.Equal(merged[0].HouseNumbers, []string{"1-21(n)", "2-20(p)"}) {
+		t.Errorf("expected the 00-624 ranges merged in order, got %+v", merged[0])
+	}
+	if merged[1].PostalCode.PostalCode != "00-001" || len(merged[2].HouseNumbers) != 0 {
+		t.Errorf("expected first-seen order and no ranges for Nowy Świat, got %+v", merged)
+	}
+
+	payload, err := json.Marshal(SearchResponse{Results: rows, merged: merged})
+	if err != nil {
+		t.Fatalf("failed to marshal response: %v", err)
+	}
+	var body struct {
+		Results []struct {
+			HouseNumbers []string `json:"house_numbers"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil || len(body.Results) != 3 || len(body.Results[0].HouseNumbers) != 2 {
+		t.Errorf("expected merged results with house number lists, got %s", payload)
+	}
+}
+
+func TestGroupByPostalCode(t *testing.T) {
+	rows := []database.PostalCode{
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("1-21(n)")},
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Nowy Świat")},
+		{PostalCode: "00-001", City: "Warszawa", Street: strPtr("Marszałkowska")},
+		{PostalCode: "00-624", City: "Warszawa", Street: strPtr("Marszałkowska"), HouseNumbers: strPtr("2-20(p)")},
+		{PostalCode: "05-077", City: "Wola"},
+	}
+
+	groups := groupByPostalCode(rows)
+	expected := []PostalCodeGroup{
+		{PostalCode: "00-624", City: "Warszawa", Streets: []string{"Marszałkowska", "Nowy Świat"}},
+		{PostalCode: "00-001", City: "Warszawa", Streets: []string{"Marszałkowska"}},
+		{PostalCode: "05-077", City: "Wola", Streets: []string{}},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("expected %d groups, got %+v", len(expected), groups)
+	}
+	for i, group := range groups {
+		if group.PostalCode != expected[i].PostalCode || group.City != expected[i].City || !slices.Equal(group.Streets, expected[i].Streets) {
+			t.Errorf("group %d: expected %+v, got %+v", i, expected[i], group)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"log/slog"
+	"time"
+
+	"postal-api/internal/config"
+)
+
+// logSlowQuery logs a structured warning when a query started at start ran longer than SLOW_QUERY_MS.
+// Call it with defer right before running the query so the timing includes reading the rows.
+func logSlowQuery(start time.Time, query string, args []interface{}) {
+	threshold := config.SlowQueryThreshold()
+	if threshold <= 0 {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < threshold {
+		return
+	}
+	slog.Warn("slow query",
+		"duration_ms", elapsed.Milliseconds(),
+		"threshold_ms", threshold.Milliseconds(),
+		"sql", query,
+		"args", args)
+}
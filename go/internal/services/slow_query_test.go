@@ -0,0 +1,53 @@
+package services
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureSlog redirects the default slog logger to a buffer for the duration of the test
+func captureSlog(t *testing.T) *bytes.Buffer {
+	var buffer bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buffer, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buffer
+}
+
+func TestLogSlowQuery(t *testing.T) {
+	query := "SELECT * FROM postal_codes WHERE city = ?"
+	args := []interface{}{"Warszawa"}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Setenv("SLOW_QUERY_MS", "")
+		buffer := captureSlog(t)
+		logSlowQuery(time.Now().Add(-time.Hour), query, args)
+		if buffer.Len() != 0 {
+			t.Errorf("expected no log, got %q", buffer.String())
+		}
+	})
+
+	t.Run("fast query", func(t *testing.T) {
+		t.Setenv("SLOW_QUERY_MS", "1000")
+		buffer := captureSlog(t)
+		logSlowQuery(time.Now(), query, args)
+		if buffer.Len() != 0 {
+			t.Errorf("expected no log, got %q", buffer.String())
+		}
+	})
+
+	t.Run("slow query", func(t *testing.T) {
+		t.Setenv("SLOW_QUERY_MS", "50")
+		buffer := captureSlog(t)
+		logSlowQuery(time.Now().Add(-200*time.Millisecond), query, args)
+		logged := buffer.String()
+		for _, want := range []string{"level=WARN", `msg="slow query"`, "threshold_ms=50", `sql="SELECT * FROM postal_codes WHERE city = ?"`, "args=[Warszawa]"} {
+			if !strings.Contains(logged, want) {
+				t.Errorf("expected %q in %q", want, logged)
+			}
+		}
+	})
+}
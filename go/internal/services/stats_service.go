@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"postal-api/internal/cache"
+	"postal-api/internal/config"
+	"postal-api/internal/database"
+)
+
+// ProvinceStats represents postal code and city counts for a province
+type ProvinceStats struct {
+	Province        string `json:"province"`
+	PostalCodeCount int    `json:"postal_code_count"`
+	CityCount       int    `json:"city_count"`
+}
+
+// StatsResponse represents the response for the stats endpoint
+type StatsResponse struct {
+	Provinces []ProvinceStats `json:"provinces"`
+	Total     ProvinceStats   `json:"total"`
+}
+
+// statsCache holds the computed stats; the data is read-only so it never expires
+var (
+	statsMu    sync.Mutex
+	statsCache *StatsResponse
+)
+
+// GetStats returns per-province postal code and city counts, computing them on first use
+func GetStats(ctx context.Context) (*StatsResponse, error) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	if statsCache != nil {
+		return statsCache, nil
+	}
+
+	db := database.GetDB()
+	query := `SELECT province, COUNT(DISTINCT postal_code), COUNT(DISTINCT city_clean)
+		FROM postal_codes WHERE province IS NOT NULL GROUP BY province ORDER BY province`
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var provinces []ProvinceStats
+	for rows.Next() {
+		var stats ProvinceStats
+		if err := rows.Scan(&stats.Province, &stats.PostalCodeCount, &stats.CityCount); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		provinces = append(provinces, stats)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	// Totals are counted directly since postal codes and city names can span provinces
+	total := ProvinceStats{Province: "total"}
+	totalQuery := "SELECT COUNT(DISTINCT postal_code), COUNT(DISTINCT city_clean) FROM postal_codes"
+	if err := db.QueryRowContext(ctx, totalQuery).Scan(&total.PostalCodeCount, &total.CityCount); err != nil {
+		return nil, fmt.Errorf("total query failed: %w", err)
+	}
+
+	statsCache = &StatsResponse{
+		Provinces: provinces,
+		Total:     total,
+	}
+	return statsCache, nil
+}
+
+// recordCountCache holds the total row count; entries expire so the count is refreshed periodically
+var recordCountCache = cache.New[int](config.LocationCacheTTL())
+
+// GetRecordCount returns the total number of postal_codes rows, refreshed after the location cache TTL
+func GetRecordCount(ctx context.Context) (int, error) {
+	if cached, ok := recordCountCache.Get(""); ok {
+		return cached, nil
+	}
+
+	var count int
+	if err := database.GetDB().QueryRowContext(ctx, "SELECT COUNT(*) FROM postal_codes").Scan(&count); err != nil {
+		return 0, fmt.Errorf("database query failed: %w", err)
+	}
+
+	recordCountCache.Set("", count)
+	return count, nil
+}
+
+// CityStreetCount is a city with the number of distinct streets it has
+type CityStreetCount struct {
+	City        string `json:"city"`
+	County      string `json:"county"`
+	Province    string `json:"province"`
+	StreetCount int    `json:"street_count"`
+}
+
+// StreetsPerCityResponse represents the response for street counts per city
+type StreetsPerCityResponse struct {
+	Cities             []CityStreetCount `json:"cities"`
+	Count              int               `json:"count"`
+	Total              int               `json:"total"`
+	Limit              int               `json:"limit"`
+	Offset             int               `json:"offset"`
+	FilteredByProvince *string           `json:"filtered_by_province,omitempty"`
+}
+
+// streetsPerCityCache holds pages of street counts per city, keyed by province filter and list options
+var streetsPerCityCache = cache.New[*StreetsPerCityResponse](config.LocationCacheTTL())
+
+// GetStreetsPerCity counts the distinct non-empty streets of each city, most streets first unless opts
+// asks for alphabetical order. Same-named places are kept apart by county and province.
+func GetStreetsPerCity(ctx context.Context, province *string, opts ListOptions) (*StreetsPerCityResponse, error) {
+	key := locationCacheKey(province) + opts.cacheKey()
+	if cached, ok := streetsPerCityCache.Get(key); ok {
+		return cached, nil
+	}
+
+	counts, total, err := listGroupedCityCounts(ctx, "street IS NOT NULL AND street != ''", "COUNT(DISTINCT street)", "", province, opts)
+	if err != nil {
+		return nil, err
+	}
+	cities := make([]CityStreetCount, len(counts))
+	for i, count := range counts {
+		cities[i] = CityStreetCount{City: count.City, County: count.County, Province: count.Province, StreetCount: count.Count}
+	}
+
+	response := &StreetsPerCityResponse{
+		Cities:             cities,
+		Count:              len(cities),
+		Total:              total,
+		Limit:              opts.Limit,
+		Offset:             opts.Offset,
+		FilteredByProvince: province,
+	}
+	streetsPerCityCache.Set(key, response)
+	return response, nil
+}
+
+// groupedCityCount is a city, told apart from same-named places by county and province, with a count over its rows
+type groupedCityCount struct {
+	City     string
+	County   string
+	Province string
+	Count    int
+}
+
+// listGroupedCityCounts pages the cities whose rows match condition, each with countExpr evaluated over its rows,
+// largest count first unless opts asks for alphabetical order. A non-empty having, such as "> 1", keeps only the
+// cities whose count passes it. It also returns the number of such cities.
+func listGroupedCityCounts(ctx context.Context, condition, countExpr, having string, province *string, opts ListOptions) ([]groupedCityCount, int, error) {
+	from := "FROM postal_codes WHERE city_clean IS NOT NULL AND county IS NOT NULL AND province IS NOT NULL"
+	if condition != "" {
+		from += " AND " + condition
+	}
+	var args []interface{}
+	from, args = appendListFilter(from, args, "province", province)
+	grouped := "SELECT city_clean, county, province, " + countExpr + " AS city_count " + from +
+		" GROUP BY city_clean, county, province"
+	if having != "" {
+		grouped += " HAVING " + countExpr + " " + having
+	}
+
+	db := database.GetDB()
+	total := 0
+	countQuery := "SELECT COUNT(*) FROM (" + grouped + ") AS city_counts"
+	start := time.Now()
+	if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("database query failed: %w", err)
+	}
+	logSlowQuery(start, countQuery, args)
+
+	direction := "ASC"
+	if opts.Descending {
+		direction = "DESC"
+	}
+	orderBy := fmt.Sprintf("city_count %s, city_clean, county", direction)
+	if opts.Sort == "alpha" {
+		orderBy = fmt.Sprintf("city_clean %s, county", direction)
+	}
+	query := grouped + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?"
+	queryArgs := append(append([]interface{}{}, args...), opts.Limit, opts.Offset)
+
+	defer logSlowQuery(time.Now(), query, queryArgs)
+	rows, err := db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("database query failed: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []groupedCityCount{}
+	for rows.Next() {
+		var count groupedCityCount
+		if err := rows.Scan(&count.City, &count.County, &count.Province, &count.Count); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan row: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+	return counts, total, nil
+}
+
+// MetaResponse describes the loaded dataset: its version and generation time, when known, and its size
+type MetaResponse struct {
+	database.Metadata
+	RecordCount int `json:"record_count"`
+}
+
+// metaCache holds the dataset metadata, refreshed after the location cache TTL like the record count
+var metaCache = cache.New[*MetaResponse](config.LocationCacheTTL())
+
+// GetMeta returns the dataset metadata and record count
+func GetMeta(ctx context.Context) (*MetaResponse, error) {
+	if cached, ok := metaCache.Get(""); ok {
+		return cached, nil
+	}
+
+	metadata, err := database.LoadMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordCount, err := GetRecordCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MetaResponse{Metadata: metadata, RecordCount: recordCount}
+	metaCache.Set("", response)
+	return response, nil
+}
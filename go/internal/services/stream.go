@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+
+	"postal-api/internal/database"
+	"postal-api/internal/utils"
+)
+
+// searchTier is one query of the tiered search
+type searchTier struct {
+	searchType    string
+	params        utils.SearchParams
+	useNormalized bool
+}
+
+// streamedTiers returns the tiers that can be read row by row: the exact search, then Polish normalization
+// unless it is disabled
+func streamedTiers(params utils.SearchParams) []searchTier {
+	tiers := []searchTier{{"exact", params, false}}
+	if !params.SkipNormalization {
+		tiers = append(tiers, searchTier{"polish_characters", utils.GetNormalizedSearchParams(params), true})
+	}
+	return tiers
+}
+
+// StreamSearch runs a search like SearchPostalCodes but passes each result to emit as soon as it is read,
+// so large result sets are never collected in memory. The exact and Polish normalization tiers stream;
+// when both find nothing, the remaining tiers run through SearchPostalCodes and their results are emitted.
+// emit receives the search type of the tier producing the rows; the returned search type is "none" when
+// nothing matched. A zero params.Limit streams every matching row of the first two tiers.
+func StreamSearch(ctx context.Context, params utils.SearchParams, emit func(searchType string, row database.PostalCode) error) (string, error) {
+	for _, tier := range streamedTiers(params) {
+		count, err := streamTier(ctx, tier.searchType, tier.params, tier.useNormalized, params, emit)
+		if err != nil {
+			return "", err
+		}
+		if count > 0 {
+			return tier.searchType, nil
+		}
+	}
+
+	// The fallback tiers return at most one page, so they are buffered. They need a page size,
+	// so an unlimited export ends after the streamed tiers.
+	if params.Limit == 0 {
+		return "none", nil
+	}
+	response, err := SearchPostalCodes(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	if response.Count == 0 {
+		return "none", nil
+	}
+	for _, row := range response.Results {
+		if err := emit(response.SearchType, row); err != nil {
+			return "", err
+		}
+	}
+	return response.SearchType, nil
+}
+
+// streamTier emits the rows of one search tier that pass the side and house number filters, up to
+// params.Limit (without a cap when it is 0), and returns how many were emitted
+func streamTier(ctx context.Context, searchType string, tierParams utils.SearchParams, useNormalized bool, params utils.SearchParams, emit func(string, database.PostalCode) error) (int, error) {
+	houseNumber := ""
+	if tierParams.HouseNumber != nil {
+		houseNumber = *tierParams.HouseNumber
+	}
+
+	// A searched house number on the other side of the street rules out every row
+	if houseNumber != "" && params.Side != "" && !utils.IsHouseNumberOnSide(houseNumber, params.Side) {
+		return 0, nil
+	}
+
+	cityName, streetName := searchedNames(params.City, params.Street)
+	count := 0
+	err := repository.SearchEach(ctx, searchType, tierParams, useNormalized, func(row database.PostalCode) error {
+		if houseNumber != "" {
+			if !matchHouseNumber(&row, houseNumber, params.AssumeAllWhenEmpty) {
+				return nil
+			}
+		} else if params.Side != "" && !coversSide(row, params.Side) {
+			return nil
+		}
+
+		row.MatchQuality = matchQuality(row, cityName, streetName)
+		if err := emit(searchType, row); err != nil {
+			return err
+		}
+		count++
+		if params.Limit > 0 && count >= params.Limit {
+			return ErrStopSearch
+		}
+		return nil
+	})
+	return count, err
+}
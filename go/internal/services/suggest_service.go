@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"postal-api/internal/utils"
+)
+
+// Suggestion types, broadest first; ties in match quality are ranked in this order
+const (
+	SuggestionProvince = "province"
+	SuggestionCounty   = "county"
+	SuggestionCity     = "city"
+	SuggestionStreet   = "street"
+)
+
+// suggestionTypeRank orders suggestion types for ranking, broadest first
+var suggestionTypeRank = map[string]int{
+	SuggestionProvince: 0,
+	SuggestionCounty:   1,
+	SuggestionCity:     2,
+	SuggestionStreet:   3,
+}
+
+// Suggestion is one labeled entity matching a search bar prefix
+type Suggestion struct {
+	Type         string `json:"type"`
+	Value        string `json:"value"`
+	MatchQuality string `json:"match_quality"`
+}
+
+// SuggestResponse represents the response for search bar suggestions
+type SuggestResponse struct {
+	Query       string       `json:"query"`
+	Suggestions []Suggestion `json:"suggestions"`
+	Count       int          `json:"count"`
+}
+
+// Suggest finds provinces, counties, cities and streets starting with q through the location list filters, so
+// Polish characters are optional. Suggestions are ranked by match quality (exact name, then prefix as typed,
+// then prefix after normalization) and then by type, broadest first; within both, each list keeps its own order
+// (cities by population). At most limit suggestions are returned.
+func Suggest(ctx context.Context, q string, limit int) (*SuggestResponse, error) {
+	opts := ListOptions{Limit: limit}
+
+	provinces, err := GetProvinces(ctx, &q, false)
+	if err != nil {
+		return nil, err
+	}
+	counties, err := GetCounties(ctx, nil, &q, opts)
+	if err != nil {
+		return nil, err
+	}
+	cities, err := GetCities(ctx, nil, nil, nil, &q, opts)
+	if err != nil {
+		return nil, err
+	}
+	// A street prefix such as "ul." alone would match every street
+	street := utils.StripStreetPrefix(q)
+	streets := &StreetResponse{}
+	if street != "" {
+		streets, err = GetStreets(ctx, nil, nil, nil, nil, &street, nil, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	suggestions := []Suggestion{}
+	add := func(suggestionType, query string, values []string) {
+		for _, value := range values[:min(len(values), limit)] {
+			suggestions = append(suggestions, Suggestion{Type: suggestionType, Value: value, MatchQuality: nameMatchQuality(query, value)})
+		}
+	}
+	add(SuggestionProvince, q, provinces.Provinces)
+	add(SuggestionCounty, q, counties.Counties)
+	add(SuggestionCity, q, cities.Cities)
+	add(SuggestionStreet, street, streets.Streets)
+
+	slices.SortStableFunc(suggestions, func(a, b Suggestion) int {
+		if rank := matchQualityRank[b.MatchQuality] - matchQualityRank[a.MatchQuality]; rank != 0 {
+			return rank
+		}
+		return suggestionTypeRank[a.Type] - suggestionTypeRank[b.Type]
+	})
+	suggestions = suggestions[:min(len(suggestions), limit)]
+
+	return &SuggestResponse{
+		Query:       q,
+		Suggestions: suggestions,
+		Count:       len(suggestions),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package utils
+
+import "math"
+
+// earthRadiusMeters is the mean Earth radius used for great-circle distances
+const earthRadiusMeters = 6371000.0
+
+// metersPerDegreeLatitude is the approximate length of one degree of latitude
+const metersPerDegreeLatitude = 111320.0
+
+// HaversineDistance returns the great-circle distance in meters between two coordinates
+func HaversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+	rLat1 := lat1 * math.Pi / 180
+	rLat2 := lat2 * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(rLat1)*math.Cos(rLat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// BoundingBox returns the latitude/longitude bounds enclosing a circle of the given radius
+func BoundingBox(lat, lon, radiusMeters float64) (minLat, maxLat, minLon, maxLon float64) {
+	latDelta := radiusMeters / metersPerDegreeLatitude
+
+	// Longitude degrees shrink towards the poles; guard against division by zero near them
+	cosLat := math.Max(math.Cos(lat*math.Pi/180), 0.01)
+	lonDelta := radiusMeters / (metersPerDegreeLatitude * cosLat)
+
+	return lat - latDelta, lat + latDelta, lon - lonDelta, lon + lonDelta
+}
+
+// IsValidLatitude checks that a latitude lies within [-90, 90]
+func IsValidLatitude(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+// IsValidLongitude checks that a longitude lies within [-180, 180]
+func IsValidLongitude(lon float64) bool {
+	return lon >= -180 && lon <= 180
+}
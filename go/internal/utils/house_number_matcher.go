@@ -1,19 +1,50 @@
 package utils
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
+// House number patterns, compiled once since the matcher runs for every candidate row
+var (
+	leadingNumberRe    = regexp.MustCompile(`^(\d+)`)
+	letterSuffixRe     = regexp.MustCompile(`^\d+([a-z]?)`)
+	letterRe           = regexp.MustCompile(`[a-z]`)
+	individualNumberRe = regexp.MustCompile(`^\d+[a-z]?$`)
+	sideIndicatorRe    = regexp.MustCompile(`\(([np])\)$`)
+	odRangeRe          = regexp.MustCompile(`^od\s*(\d+[a-z]?)$`)
+	dkRangeRe          = regexp.MustCompile(`(?i)^(\d+[a-z]?)-DK`)
+	regularRangeRe     = regexp.MustCompile(`^(\d+[a-z]?)-(\d+[a-z]?)$`)
+	complexSlashRe     = regexp.MustCompile(`^(\d+)/(\d+)-(\d+)/(\d+)(\([np]\))?$`)
+	slashListRe        = regexp.MustCompile(`^\d+(/\d+)+$`)
+	slashRangeRe       = regexp.MustCompile(`^(\d+)-(\d+)/(\d+)(\([np]\))?$`)
+	slashStartRe       = regexp.MustCompile(`^(\d+)/(\d+)-(\d+)(\([np]\))?$`)
+	slashCharsRe       = regexp.MustCompile(`^[\d/-]+$`)
+	digitsRe           = regexp.MustCompile(`\d+`)
+	rangeSeparatorRe   = regexp.MustCompile(`\s*([-/])\s*`)
+	sideSpacingRe      = regexp.MustCompile(`\s+\(`)
+)
+
+// normalizeRangeString lowercases a range string and removes stray spaces around dashes, slashes
+// and side indicators, so "270 - 336" and "1-41 (n)" parse like "270-336" and "1-41(n)"
+func normalizeRangeString(rangeString string) string {
+	rangeString = strings.ToLower(strings.TrimSpace(rangeString))
+	if !strings.Contains(rangeString, " ") {
+		return rangeString
+	}
+	rangeString = rangeSeparatorRe.ReplaceAllString(rangeString, "$1")
+	return sideSpacingRe.ReplaceAllString(rangeString, "(")
+}
+
 // extractNumericPart extracts the numeric part from a house number like "123a" -> 123
 func extractNumericPart(houseNumber string) (int, bool) {
 	if houseNumber == "" {
 		return 0, false
 	}
 
-	re := regexp.MustCompile(`^(\d+)`)
-	matches := re.FindStringSubmatch(strings.TrimSpace(houseNumber))
+	matches := leadingNumberRe.FindStringSubmatch(strings.TrimSpace(houseNumber))
 	if len(matches) > 1 {
 		if num, err := strconv.Atoi(matches[1]); err == nil {
 			return num, true
@@ -34,31 +65,57 @@ func isEven(number int) bool {
 
 // rangeEndpoints represents parsed range endpoints
 type rangeEndpoints struct {
-	startNum        int
-	endNum          int
-	isDK            bool
-	hasLetterStart  bool
-	hasLetterEnd    bool
-	valid           bool
+	startNum       int
+	endNum         int
+	isDK           bool
+	hasLetterStart bool
+	hasLetterEnd   bool
+	startLetter    string
+	endLetter      string
+	valid          bool
+}
+
+// extractLetterSuffix extracts the letter suffix from a house number like "12b" -> "b"
+func extractLetterSuffix(houseNumber string) string {
+	matches := letterSuffixRe.FindStringSubmatch(strings.TrimSpace(houseNumber))
+	if len(matches) > 1 {
+		return matches[1]
+	}
+	return ""
 }
 
-// parseRangeEndpoints parses range endpoints from strings like "270-336", "4a-9", "55-DK"
+// parseRangeEndpoints parses range endpoints from strings like "270-336", "4a-9", "55-DK", "od 10"
 func parseRangeEndpoints(rangePart string) rangeEndpoints {
+	// Handle textual "od N" (from N) ranges, equivalent to "N-DK"
+	if matches := odRangeRe.FindStringSubmatch(strings.ToLower(strings.TrimSpace(rangePart))); len(matches) > 1 {
+		startStr := matches[1]
+		if startNum, hasStart := extractNumericPart(startStr); hasStart {
+			hasLetterStart := letterRe.MatchString(startStr)
+			return rangeEndpoints{
+				startNum:       startNum,
+				isDK:           true,
+				hasLetterStart: hasLetterStart,
+				startLetter:    extractLetterSuffix(startStr),
+				valid:          true,
+			}
+		}
+	}
+
 	// Handle DK (do końca / to the end) ranges
 	if strings.Contains(strings.ToUpper(rangePart), "DK") {
-		re := regexp.MustCompile(`^(\d+[a-z]?)-DK`)
-		matches := re.FindStringSubmatch(rangePart)
+		matches := dkRangeRe.FindStringSubmatch(rangePart)
 		if len(matches) > 1 {
 			startStr := matches[1]
 			startNum, hasStart := extractNumericPart(startStr)
 			if hasStart {
-				hasLetterStart := regexp.MustCompile(`[a-z]`).MatchString(startStr)
+				hasLetterStart := letterRe.MatchString(startStr)
 				return rangeEndpoints{
 					startNum:       startNum,
 					endNum:         0,
 					isDK:           true,
 					hasLetterStart: hasLetterStart,
 					hasLetterEnd:   false,
+					startLetter:    extractLetterSuffix(startStr),
 					valid:          true,
 				}
 			}
@@ -66,22 +123,23 @@ func parseRangeEndpoints(rangePart string) rangeEndpoints {
 	}
 
 	// Handle regular ranges like "270-336" or "4a-9b"
-	re := regexp.MustCompile(`^(\d+[a-z]?)-(\d+[a-z]?)$`)
-	matches := re.FindStringSubmatch(rangePart)
+	matches := regularRangeRe.FindStringSubmatch(rangePart)
 	if len(matches) > 2 {
 		startStr := matches[1]
 		endStr := matches[2]
 		startNum, hasStart := extractNumericPart(startStr)
 		endNum, hasEnd := extractNumericPart(endStr)
 		if hasStart && hasEnd {
-			hasLetterStart := regexp.MustCompile(`[a-z]`).MatchString(startStr)
-			hasLetterEnd := regexp.MustCompile(`[a-z]`).MatchString(endStr)
+			hasLetterStart := letterRe.MatchString(startStr)
+			hasLetterEnd := letterRe.MatchString(endStr)
 			return rangeEndpoints{
 				startNum:       startNum,
 				endNum:         endNum,
 				isDK:           false,
 				hasLetterStart: hasLetterStart,
 				hasLetterEnd:   hasLetterEnd,
+				startLetter:    extractLetterSuffix(startStr),
+				endLetter:      extractLetterSuffix(endStr),
 				valid:          true,
 			}
 		}
@@ -90,7 +148,7 @@ func parseRangeEndpoints(rangePart string) rangeEndpoints {
 	return rangeEndpoints{valid: false}
 }
 
-// handleSlashNotation handles slash notation patterns like "2/4", "55-69/71", "2/4-10", "1/3-23/25(n)"
+// handleSlashNotation handles slash notation patterns like "2/4", "1/3/5", "55-69/71", "2/4-10", "1/3-23/25(n)"
 func handleSlashNotation(houseNumber, rangeString string) bool {
 	houseNum, hasHouseNum := extractNumericPart(houseNumber)
 	if !hasHouseNum {
@@ -98,7 +156,6 @@ func handleSlashNotation(houseNumber, rangeString string) bool {
 	}
 
 	// Pattern: "1/3-23/25(n)" - complex pattern with multiple slashes and ranges
-	complexSlashRe := regexp.MustCompile(`^(\d+)/(\d+)-(\d+)/(\d+)(\([np]\))?$`)
 	if matches := complexSlashRe.FindStringSubmatch(rangeString); len(matches) > 4 {
 		start1, _ := strconv.Atoi(matches[1])
 		start2, _ := strconv.Atoi(matches[2])
@@ -126,8 +183,8 @@ func handleSlashNotation(houseNumber, rangeString string) bool {
 		return true
 	}
 
-	// Pattern: "2/4" - individual numbers separated by slash
-	if regexp.MustCompile(`^\d+/\d+$`).MatchString(rangeString) {
+	// Pattern: "2/4" or "1/3/5" - individual numbers separated by slashes
+	if slashListRe.MatchString(rangeString) {
 		numbers := strings.Split(rangeString, "/")
 		for _, numStr := range numbers {
 			if num, err := strconv.Atoi(numStr); err == nil && num == houseNum {
@@ -138,7 +195,6 @@ func handleSlashNotation(houseNumber, rangeString string) bool {
 	}
 
 	// Pattern: "55-69/71" or "55-69/71(n)" - range with specific end points
-	slashRangeRe := regexp.MustCompile(`^(\d+)-(\d+)/(\d+)(\([np]\))?$`)
 	if matches := slashRangeRe.FindStringSubmatch(rangeString); len(matches) > 3 {
 		start, _ := strconv.Atoi(matches[1])
 		mid, _ := strconv.Atoi(matches[2])
@@ -166,7 +222,6 @@ func handleSlashNotation(houseNumber, rangeString string) bool {
 	}
 
 	// Pattern: "2/4-10" or "2/4-10(p)" - slash number plus range
-	slashStartRe := regexp.MustCompile(`^(\d+)/(\d+)-(\d+)(\([np]\))?$`)
 	if matches := slashStartRe.FindStringSubmatch(rangeString); len(matches) > 3 {
 		start2, _ := strconv.Atoi(matches[2])
 		end, _ := strconv.Atoi(matches[3])
@@ -203,14 +258,24 @@ func IsHouseNumberInRange(houseNumber, rangeString string) bool {
 		return false
 	}
 
-	// Clean inputs
-	houseNumber = strings.TrimSpace(houseNumber)
-	rangeString = strings.TrimSpace(rangeString)
+	// Clean inputs (letter suffixes are compared case-insensitively)
+	houseNumber = strings.ToLower(strings.TrimSpace(houseNumber))
+	rangeString = normalizeRangeString(rangeString)
 
 	if houseNumber == "" || rangeString == "" {
 		return false
 	}
 
+	// Handle comma-separated lists like "1,3,5" or "2-8,14" - any token may match
+	if strings.Contains(rangeString, ",") {
+		for _, token := range strings.Split(rangeString, ",") {
+			if IsHouseNumberInRange(houseNumber, token) {
+				return true
+			}
+		}
+		return false
+	}
+
 	// Extract numeric part of the house number
 	houseNum, hasHouseNum := extractNumericPart(houseNumber)
 	if !hasHouseNum {
@@ -218,9 +283,9 @@ func IsHouseNumberInRange(houseNumber, rangeString string) bool {
 	}
 
 	// Handle individual numbers (exact match)
-	if regexp.MustCompile(`^\d+[a-z]?$`).MatchString(rangeString) {
+	if individualNumberRe.MatchString(rangeString) {
 		// For individual numbers with letters, require exact match
-		if regexp.MustCompile(`[a-z]`).MatchString(rangeString) {
+		if letterRe.MatchString(rangeString) {
 			return houseNumber == rangeString
 		}
 		// For pure numeric individual numbers, allow numeric match
@@ -240,10 +305,9 @@ func IsHouseNumberInRange(houseNumber, rangeString string) bool {
 	baseRange := rangeString
 
 	// Check for side indicators: (n) = odd, (p) = even
-	sideRe := regexp.MustCompile(`\(([np])\)$`)
-	if matches := sideRe.FindStringSubmatch(rangeString); len(matches) > 1 {
+	if matches := sideIndicatorRe.FindStringSubmatch(rangeString); len(matches) > 1 {
 		sideIndicator = matches[1]
-		baseRange = rangeString[:sideRe.FindStringIndex(rangeString)[0]]
+		baseRange = rangeString[:sideIndicatorRe.FindStringIndex(rangeString)[0]]
 	}
 
 	// Parse the range
@@ -258,10 +322,15 @@ func IsHouseNumberInRange(houseNumber, rangeString string) bool {
 	if endpoints.isDK {
 		// DK range: house_num >= start_num
 		// Special case: if start has letter (e.g., "6a-DK"), plain number equal to start should NOT match
-		if endpoints.hasLetterStart && !regexp.MustCompile(`[a-z]`).MatchString(houseNumber) && houseNum == endpoints.startNum {
+		if endpoints.hasLetterStart && !letterRe.MatchString(houseNumber) && houseNum == endpoints.startNum {
 			return false // "6" should not match "6a-DK", but "8" should
 		}
 		inRange = houseNum >= endpoints.startNum
+	} else if endpoints.endNum > 0 && endpoints.startNum == endpoints.endNum && (endpoints.hasLetterStart || endpoints.hasLetterEnd) {
+		// Letter range on a single base number like "12a-12f" or "31-31a": compare letter suffixes,
+		// where a plain number sorts before any letter ("12" is not within "12a-12f")
+		houseLetter := extractLetterSuffix(houseNumber)
+		inRange = houseNum == endpoints.startNum && endpoints.startLetter <= houseLetter && houseLetter <= endpoints.endLetter
 	} else if endpoints.endNum > 0 {
 		// Regular range: start_num <= house_num <= end_num
 		inRange = endpoints.startNum <= houseNum && houseNum <= endpoints.endNum
@@ -283,4 +352,183 @@ func IsHouseNumberInRange(houseNumber, rangeString string) bool {
 
 	// No side constraint, any house number in range is valid
 	return true
-}
\ No newline at end of file
+}
+
+// Notation names reported by ParseHouseNumberRange
+const (
+	NotationSingle  = "single"
+	NotationRegular = "regular"
+	NotationDK      = "dk"
+	NotationSlash   = "slash"
+	NotationList    = "list"
+)
+
+// HouseNumberRange is a structured interpretation of a house number range string
+type HouseNumberRange struct {
+	Start          int    `json:"start"`
+	End            int    `json:"end,omitempty"`
+	Side           string `json:"side,omitempty"`
+	IsDK           bool   `json:"is_dk"`
+	HasLetterStart bool   `json:"has_letter_start"`
+	HasLetterEnd   bool   `json:"has_letter_end"`
+	Notation       string `json:"notation,omitempty"`
+	Valid          bool   `json:"valid"`
+	Explanation    string `json:"explanation,omitempty"`
+}
+
+// invalidRange builds an invalid interpretation with an explanation
+func invalidRange(explanation string) HouseNumberRange {
+	return HouseNumberRange{Valid: false, Explanation: explanation}
+}
+
+// ParseHouseNumberRange interprets a range string like "1-41(n)", "337-DK" or "1/3-23/25(n)"
+func ParseHouseNumberRange(rangeString string) HouseNumberRange {
+	rangeString = normalizeRangeString(rangeString)
+	if rangeString == "" {
+		return invalidRange("Range string is empty")
+	}
+
+	// Comma-separated list: every token must be valid, bounds come from the first and last tokens
+	if strings.Contains(rangeString, ",") {
+		tokens := strings.Split(rangeString, ",")
+		var first, last HouseNumberRange
+		for i, token := range tokens {
+			parsed := ParseHouseNumberRange(token)
+			if !parsed.Valid {
+				return invalidRange(fmt.Sprintf("List item '%s' is invalid: %s", strings.TrimSpace(token), parsed.Explanation))
+			}
+			if i == 0 {
+				first = parsed
+			}
+			last = parsed
+		}
+		end := last.End
+		if end == 0 {
+			end = last.Start
+		}
+		return HouseNumberRange{Start: first.Start, End: end, Notation: NotationList, Valid: true}
+	}
+
+	// Extract side indicator: (n) = odd, (p) = even
+	side := ""
+	baseRange := rangeString
+	if matches := sideIndicatorRe.FindStringSubmatch(rangeString); len(matches) > 1 {
+		if matches[1] == "n" {
+			side = "odd"
+		} else {
+			side = "even"
+		}
+		baseRange = rangeString[:sideIndicatorRe.FindStringIndex(rangeString)[0]]
+	}
+
+	// Individual number like "60" or "35c"
+	if individualNumberRe.MatchString(baseRange) {
+		num, _ := extractNumericPart(baseRange)
+		return HouseNumberRange{
+			Start:          num,
+			End:            num,
+			Side:           side,
+			HasLetterStart: letterRe.MatchString(baseRange),
+			Notation:       NotationSingle,
+			Valid:          true,
+		}
+	}
+
+	// Slash notation: report the outermost numbers as the bounds
+	if strings.Contains(baseRange, "/") {
+		if !slashCharsRe.MatchString(baseRange) {
+			return invalidRange(fmt.Sprintf("Slash notation '%s' may only contain digits, '/' and '-'", rangeString))
+		}
+		numbers := digitsRe.FindAllString(baseRange, -1)
+		if len(numbers) == 0 {
+			return invalidRange(fmt.Sprintf("Slash notation '%s' contains no numbers", rangeString))
+		}
+		start, _ := strconv.Atoi(numbers[0])
+		end, _ := strconv.Atoi(numbers[len(numbers)-1])
+		return HouseNumberRange{Start: start, End: end, Side: side, Notation: NotationSlash, Valid: true}
+	}
+
+	endpoints := parseRangeEndpoints(baseRange)
+	if !endpoints.valid {
+		return invalidRange(fmt.Sprintf("Range '%s' does not match any supported notation (single, regular, DK or slash)", rangeString))
+	}
+
+	notation := NotationRegular
+	if endpoints.isDK {
+		notation = NotationDK
+	}
+
+	return HouseNumberRange{
+		Start:          endpoints.startNum,
+		End:            endpoints.endNum,
+		Side:           side,
+		IsDK:           endpoints.isDK,
+		HasLetterStart: endpoints.hasLetterStart,
+		HasLetterEnd:   endpoints.hasLetterEnd,
+		Notation:       notation,
+		Valid:          true,
+	}
+}
+
+// Side values accepted by the side filter
+const (
+	SideOdd  = "odd"
+	SideEven = "even"
+)
+
+// IsHouseNumberOnSide checks whether a house number has the requested parity
+func IsHouseNumberOnSide(houseNumber, side string) bool {
+	houseNum, hasHouseNum := extractNumericPart(houseNumber)
+	if !hasHouseNum {
+		return false
+	}
+	if side == SideOdd {
+		return isOdd(houseNum)
+	}
+	return isEven(houseNum)
+}
+
+// RangeIncludesSide checks whether a range string can contain house numbers with the requested parity.
+// Ranges with (n)/(p) indicators only cover one side, single numbers cover the side of their parity,
+// and ranges without an indicator cover both sides.
+func RangeIncludesSide(rangeString, side string) bool {
+	rangeString = strings.TrimSpace(rangeString)
+	if strings.Contains(rangeString, ",") {
+		for _, token := range strings.Split(rangeString, ",") {
+			if RangeIncludesSide(token, side) {
+				return true
+			}
+		}
+		return false
+	}
+
+	parsed := ParseHouseNumberRange(rangeString)
+	if !parsed.Valid {
+		return false
+	}
+	if parsed.Side != "" {
+		return parsed.Side == side
+	}
+	if parsed.Notation == NotationSingle {
+		return IsHouseNumberOnSide(rangeString, side)
+	}
+	return true
+}
+
+// MatchingRange returns the part of a range string that a house number falls into.
+// For comma-separated lists this is the matching item, otherwise the whole range string.
+func MatchingRange(houseNumber, rangeString string) (string, bool) {
+	if strings.Contains(rangeString, ",") {
+		for _, token := range strings.Split(rangeString, ",") {
+			if IsHouseNumberInRange(houseNumber, token) {
+				return strings.TrimSpace(token), true
+			}
+		}
+		return "", false
+	}
+
+	if IsHouseNumberInRange(houseNumber, rangeString) {
+		return strings.TrimSpace(rangeString), true
+	}
+	return "", false
+}
@@ -0,0 +1,259 @@
+package utils
+
+import "testing"
+
+func TestIsHouseNumberInRangeCommaLists(t *testing.T) {
+	tests := []struct {
+		houseNumber string
+		rangeString string
+		expected    bool
+	}{
+		{"1", "1,3,5", true},
+		{"3", "1,3,5", true},
+		{"5", "1,3,5", true},
+		{"4", "1,3,5", false},
+		{"2", "2-8,14", true},
+		{"6", "2-8,14", true},
+		{"14", "2-8,14", true},
+		{"10", "2-8,14", false},
+		{"7", "1-9(n),2-10(p)", true},
+		{"8", "1-9(n),2-10(p)", true},
+		{"11", "1-9(n),2-10(p)", false},
+		{"12", "1-11(n), 12-DK", true},
+		{"4", "1-11(n), 12-DK", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsHouseNumberInRange(tt.houseNumber, tt.rangeString); got != tt.expected {
+			t.Errorf("IsHouseNumberInRange(%q, %q) = %v, want %v", tt.houseNumber, tt.rangeString, got, tt.expected)
+		}
+	}
+}
+
+func TestIsHouseNumberInRangeLetterRanges(t *testing.T) {
+	tests := []struct {
+		houseNumber string
+		rangeString string
+		expected    bool
+	}{
+		{"12a", "12a-12f", true},
+		{"12b", "12a-12f", true},
+		{"12f", "12a-12f", true},
+		{"12g", "12a-12f", false},
+		{"12", "12a-12f", false},
+		{"13b", "12a-12f", false},
+		{"12B", "12a-12f", true},
+		{"12c", "12A-12F", true},
+		{"12G", "12A-12F", false},
+		{"31", "31-31a", true},
+		{"31a", "31-31a", true},
+		{"31b", "31-31a", false},
+		{"8", "4a-9b", true},
+		{"10", "4a-9b", false},
+		{"10", "6A-DK", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsHouseNumberInRange(tt.houseNumber, tt.rangeString); got != tt.expected {
+			t.Errorf("IsHouseNumberInRange(%q, %q) = %v, want %v", tt.houseNumber, tt.rangeString, got, tt.expected)
+		}
+	}
+}
+
+func TestIsHouseNumberInRangeOdRanges(t *testing.T) {
+	tests := []struct {
+		houseNumber string
+		rangeString string
+		expected    bool
+	}{
+		{"14", "od 10", true},
+		{"10", "od 10", true},
+		{"8", "od 10", false},
+		{"14", "od10", true},
+		{"14", "  OD   10 ", true},
+		{"8", "Od 10", false},
+		{"13", "od 10(n)", true},
+		{"14", "od 10(n)", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsHouseNumberInRange(tt.houseNumber, tt.rangeString); got != tt.expected {
+			t.Errorf("IsHouseNumberInRange(%q, %q) = %v, want %v", tt.houseNumber, tt.rangeString, got, tt.expected)
+		}
+	}
+}
+
+func TestRangeIncludesSide(t *testing.T) {
+	tests := []struct {
+		rangeString string
+		side        string
+		expected    bool
+	}{
+		{"1-41(n)", SideOdd, true},
+		{"1-41(n)", SideEven, false},
+		{"2-38(p)", SideEven, true},
+		{"1-12", SideOdd, true},
+		{"1-12", SideEven, true},
+		{"60", SideEven, true},
+		{"60", SideOdd, false},
+		{"1-9(n),2-10(p)", SideEven, true},
+	}
+
+	for _, tt := range tests {
+		if got := RangeIncludesSide(tt.rangeString, tt.side); got != tt.expected {
+			t.Errorf("RangeIncludesSide(%q, %q) = %v, want %v", tt.rangeString, tt.side, got, tt.expected)
+		}
+	}
+}
+
+// TestIsHouseNumberInRangeNotations pins down the matcher's contract for every notation branch
+func TestIsHouseNumberInRangeNotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		houseNumber string
+		rangeString string
+		expected    bool
+	}{
+		{"single exact", "60", "60", true},
+		{"single other number", "61", "60", false},
+		{"single with letter matches its number", "60a", "60", true},
+		{"single letter requires exact letter", "35", "35c", false},
+		{"single letter ignores case", "35C", "35c", true},
+		{"regular start", "270", "270-336", true},
+		{"regular end", "336", "270-336", true},
+		{"regular outside", "337", "270-336", false},
+		{"odd side inside", "17", "1-41(n)", true},
+		{"odd side wrong parity", "18", "1-41(n)", false},
+		{"odd side past end", "43", "1-41(n)", false},
+		{"even side inside", "18", "2-38(p)", true},
+		{"even side wrong parity", "17", "2-38(p)", false},
+		{"dk start", "337", "337-DK", true},
+		{"dk far end", "1000", "337-DK", true},
+		{"dk before start", "336", "337-DK", false},
+		{"dk letter start excludes plain start", "6", "6a-DK", false},
+		{"dk letter start", "6a", "6a-DK", true},
+		{"dk letter start later letter", "6b", "6a-DK", true},
+		{"dk letter start next number", "7", "6a-DK", true},
+		{"dk with side", "3", "1-DK(n)", true},
+		{"dk with side wrong parity", "4", "1-DK(n)", false},
+		{"slash pair first", "2", "2/4", true},
+		{"slash pair second", "4", "2/4", true},
+		{"slash pair between", "3", "2/4", false},
+		{"slash list first", "1", "1/3/5", true},
+		{"slash list middle", "3", "1/3/5", true},
+		{"slash list last", "5", "1/3/5", true},
+		{"slash list gap", "4", "1/3/5", false},
+		{"slash list of four", "7", "1/3/5/7", true},
+		{"slash range inside", "57", "55-69/71(n)", true},
+		{"slash range extra end", "71", "55-69/71(n)", true},
+		{"slash range gap", "70", "55-69/71(n)", false},
+		{"slash range wrong parity", "58", "55-69/71(n)", false},
+		{"slash start range", "8", "2/4-10(p)", true},
+		{"slash start range second number", "4", "2/4-10(p)", true},
+		{"slash start range first number", "2", "2/4-10(p)", false},
+		{"slash start range wrong parity", "9", "2/4-10(p)", false},
+		{"double slash first", "1", "1/3-23/25(n)", true},
+		{"double slash last", "25", "1/3-23/25(n)", true},
+		{"double slash between", "5", "1/3-23/25(n)", false},
+		{"non-numeric house number", "abc", "1-5", false},
+		{"empty house number", "", "1", false},
+		{"empty range", "5", "", false},
+		{"unparseable range", "5", "garbage", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsHouseNumberInRange(tt.houseNumber, tt.rangeString); got != tt.expected {
+				t.Errorf("IsHouseNumberInRange(%q, %q) = %v, want %v", tt.houseNumber, tt.rangeString, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsHouseNumberInRangeSpacedRanges(t *testing.T) {
+	tests := []struct {
+		spaced  string
+		compact string
+	}{
+		{"270 - 336", "270-336"},
+		{"1 -41(n)", "1-41(n)"},
+		{"1-41 (n)", "1-41(n)"},
+		{"55-69 / 71", "55-69/71"},
+		{"55 - 69 / 71 (n)", "55-69/71(n)"},
+		{"2 / 4", "2/4"},
+		{"1 / 3 / 5", "1/3/5"},
+		{"2/4 - 10(p)", "2/4-10(p)"},
+		{"337 - DK", "337-DK"},
+		{"6a - DK", "6a-DK"},
+		{"1 - 11(n), 12 - DK", "1-11(n),12-DK"},
+	}
+
+	for _, tt := range tests {
+		for _, houseNumber := range []string{"2", "3", "4", "6", "10", "57", "58", "71", "300", "400"} {
+			want := IsHouseNumberInRange(houseNumber, tt.compact)
+			if got := IsHouseNumberInRange(houseNumber, tt.spaced); got != want {
+				t.Errorf("IsHouseNumberInRange(%q, %q) = %v, want %v as for %q", houseNumber, tt.spaced, got, want, tt.compact)
+			}
+		}
+		if parsed := ParseHouseNumberRange(tt.spaced); !parsed.Valid {
+			t.Errorf("ParseHouseNumberRange(%q) is invalid: %s", tt.spaced, parsed.Explanation)
+		}
+	}
+}
+
+// benchmarkRanges is a mix of the notations found in the house_numbers column
+var benchmarkRanges = []string{"1-41(n)", "2-38(p)", "60", "35c", "337-DK", "6a-DK", "55-69/71(n)", "2/4-10(p)", "1/3-23/25(n)", "12a-12f", "1-11(n), 12-DK"}
+
+// BenchmarkIsHouseNumberInRange filters a page of candidate rows the way filterByHouseNumber does
+func BenchmarkIsHouseNumberInRange(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, rangeString := range benchmarkRanges {
+			IsHouseNumberInRange("17", rangeString)
+		}
+	}
+}
+
+// BenchmarkIsHouseNumberInRangeNotations measures each notation branch on its own to localize regressions
+func BenchmarkIsHouseNumberInRangeNotations(b *testing.B) {
+	benchmarks := []struct {
+		name        string
+		houseNumber string
+		rangeString string
+	}{
+		{"single", "60", "60"},
+		{"single_letter", "35c", "35c"},
+		{"regular", "300", "270-336"},
+		{"letter_range", "12c", "12a-12f"},
+		{"dk", "400", "337-DK"},
+		{"dk_letter_start", "6", "6a-DK"},
+		{"od", "14", "od 10"},
+		{"side_odd", "17", "1-41(n)"},
+		{"side_even", "18", "2-38(p)"},
+		{"slash_pair", "4", "2/4"},
+		{"slash_list", "5", "1/3/5"},
+		{"slash_range", "71", "55-69/71(n)"},
+		{"slash_start", "8", "2/4-10(p)"},
+		{"double_slash", "25", "1/3-23/25(n)"},
+		{"list", "12", "1-11(n), 12-DK"},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				IsHouseNumberInRange(bm.houseNumber, bm.rangeString)
+			}
+		})
+	}
+}
+
+// BenchmarkParseHouseNumberRange measures the structured parser behind /house-number/parse and the side filter
+func BenchmarkParseHouseNumberRange(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, rangeString := range benchmarkRanges {
+			ParseHouseNumberRange(rangeString)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package utils
+
+import "strings"
+
+// LevenshteinDistance returns the number of single-rune edits needed to turn a into b
+func LevenshteinDistance(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// FuzzyKey prepares text for edit-distance comparison by lowercasing and removing Polish characters
+func FuzzyKey(text string) string {
+	return strings.ToLower(NormalizePolishText(strings.TrimSpace(text)))
+}
+
+// FuzzyThreshold returns the maximum edit distance tolerated for an input, scaled to its length
+func FuzzyThreshold(text string) int {
+	length := len([]rune(text))
+	return max(1, min(3, length/4))
+}
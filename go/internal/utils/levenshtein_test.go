@@ -0,0 +1,23 @@
+package utils
+
+import "testing"
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"warszawa", "warszawa", 0},
+		{"warsawa", "warszawa", 1},
+		{"krakuw", "krakow", 1},
+		{"łódź", "lodz", 3},
+	}
+
+	for _, tt := range tests {
+		if got := LevenshteinDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("LevenshteinDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// phoneticDigraphs are letter pairs spelling a single Polish sound, or its usual English spelling, checked before
+// single letters
+var phoneticDigraphs = map[string]string{
+	"ch": "H",
+	"cz": "C",
+	"sz": "S",
+	"rz": "S",
+	"sh": "S",
+	"zh": "S",
+	"ts": "C",
+	"tz": "C",
+	"dz": "C",
+	"dź": "C",
+	"dż": "C",
+	"ck": "K",
+	"ph": "F",
+	"th": "T",
+}
+
+// phoneticLetters groups letters that sound alike in Polish, merging voiced and voiceless pairs and soft forms.
+// The nasal vowels ą and ę sound like "on" and "en" and count as "n"; other vowels map to "" and only count at
+// the start of a word.
+var phoneticLetters = map[rune]string{
+	'a': "", 'e': "", 'i': "", 'o': "", 'ó': "", 'u': "", 'y': "", 'j': "",
+	'b': "P", 'p': "P",
+	'd': "T", 't': "T",
+	'g': "K", 'k': "K", 'q': "K",
+	'w': "F", 'f': "F", 'v': "F",
+	's': "S", 'ś': "S", 'z': "S", 'ź': "S", 'ż': "S",
+	'c': "C", 'ć': "C",
+	'l': "L", 'ł': "L",
+	'n': "N", 'ń': "N", 'ą': "N", 'ę': "N",
+	'h': "H", 'm': "M", 'r': "R", 'x': "KS",
+}
+
+// PhoneticKey returns a Soundex-like key of a Polish name, equal for names that sound alike even when spelled
+// phonetically, e.g. "Kshesznyce" and "Krzesznice". Consonants are reduced to sound classes, vowels are dropped
+// except at the start of a word and repeated classes are collapsed. Words are separated by a space.
+func PhoneticKey(text string) string {
+	var key strings.Builder
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if key.Len() > 0 {
+			key.WriteByte(' ')
+		}
+		key.WriteString(phoneticWordKey([]rune(word)))
+	}
+	return key.String()
+}
+
+// phoneticWordKey encodes a single lowercase word for PhoneticKey
+func phoneticWordKey(word []rune) string {
+	var key strings.Builder
+	last := ""
+	for i := 0; i < len(word); i++ {
+		code, known := "", false
+		if i+1 < len(word) {
+			code, known = phoneticDigraphs[string(word[i:i+2])]
+		}
+		if known {
+			i++
+		} else {
+			code, known = phoneticLetters[word[i]]
+		}
+		if !known {
+			continue
+		}
+		if code == "" {
+			// A leading vowel is kept so "Olsztyn" and "Lsztyn" differ
+			if i == 0 {
+				key.WriteByte('A')
+			}
+			continue
+		}
+		if code != last {
+			key.WriteString(code)
+		}
+		last = code
+	}
+	return key.String()
+}
@@ -0,0 +1,43 @@
+package utils
+
+import "testing"
+
+func TestPhoneticKeyMatchesSoundAlikes(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Kshesznyce", "Krzesznice"},
+		{"Sztsetsin", "Szczecin"},
+		{"Gdansk", "Gdańsk"},
+		{"Lodz", "Łódź"},
+		{"Zhyrardov", "Żyrardów"},
+		{"Czenstohowa", "Częstochowa"},
+		{"Bidgoszcz", "Bydgoszcz"},
+		{"Nowa Sól", "nova sul"},
+	}
+
+	for _, tt := range tests {
+		if a, b := PhoneticKey(tt.a), PhoneticKey(tt.b); a != b {
+			t.Errorf("PhoneticKey(%q) = %q, PhoneticKey(%q) = %q, want equal keys", tt.a, a, tt.b, b)
+		}
+	}
+}
+
+func TestPhoneticKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"Kraków", "KRKF"},
+		{"Olsztyn", "ALSTN"},
+		{"Nowa Sól", "NF SL"},
+		{"Bielsko-Biała", "PLSK PL"},
+	}
+
+	for _, tt := range tests {
+		if got := PhoneticKey(tt.input); got != tt.expected {
+			t.Errorf("PhoneticKey(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
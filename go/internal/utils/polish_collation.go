@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// SortedPolish returns a copy of values sorted by Polish collation rules, so "Łódź" sorts between "L" and "M"
+func SortedPolish(values []string) []string {
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+
+	// Collators are not safe for concurrent use, so each call gets its own
+	collator := collate.New(language.Polish, collate.IgnoreCase)
+	collator.SortStrings(sorted)
+	return sorted
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortedPolish(t *testing.T) {
+	input := []string{"Zamość", "Łódź", "Mielec", "Lublin", "Świdnik", "Sopot", "Ełk", "Elbląg"}
+	expected := []string{"Elbląg", "Ełk", "Lublin", "Łódź", "Mielec", "Sopot", "Świdnik", "Zamość"}
+
+	got := SortedPolish(input)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortedPolish(%v) = %v, want %v", input, got, expected)
+	}
+
+	// The input slice must be left untouched
+	if input[0] != "Zamość" {
+		t.Errorf("SortedPolish modified its input: %v", input)
+	}
+}
+
+func TestSortedPolishLBeforeŁBeforeM(t *testing.T) {
+	got := SortedPolish([]string{"Mława", "Łomża", "Lwówek"})
+	expected := []string{"Lwówek", "Łomża", "Mława"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortedPolish = %v, want %v", got, expected)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"strings"
+	"sync/atomic"
 )
 
 // polishCharMap maps Polish characters to ASCII equivalents
@@ -29,8 +30,39 @@ var polishCharMap = map[rune]rune{
 	'Ż': 'Z',
 }
 
-// NormalizePolishText converts Polish characters to ASCII equivalents
+// activeCharMap is the map used by NormalizePolishText: the Polish map plus any registered mappings.
+// It is replaced wholesale on registration so readers never need a lock.
+var activeCharMap atomic.Pointer[map[rune]rune]
+
+func init() {
+	activeCharMap.Store(&polishCharMap)
+}
+
+// RegisterCharMappings adds rune mappings (e.g. German or Czech diacritics) used by NormalizePolishText.
+// It is intended to be called at startup; existing mappings for the same runes are overridden.
+func RegisterCharMappings(mappings map[rune]rune) {
+	for {
+		current := activeCharMap.Load()
+		merged := make(map[rune]rune, len(*current)+len(mappings))
+		for from, to := range *current {
+			merged[from] = to
+		}
+		for from, to := range mappings {
+			merged[from] = to
+		}
+		if activeCharMap.CompareAndSwap(current, &merged) {
+			return
+		}
+	}
+}
+
+// NormalizePolishText converts Polish characters (and any registered mappings) to ASCII equivalents
 func NormalizePolishText(text string) string {
+	return NormalizeWithMap(text, *activeCharMap.Load())
+}
+
+// NormalizeWithMap converts characters using the given rune mapping, leaving unmapped characters unchanged
+func NormalizeWithMap(text string, charMap map[rune]rune) string {
 	if text == "" {
 		return text
 	}
@@ -39,7 +71,7 @@ func NormalizePolishText(text string) string {
 	result.Grow(len(text))
 
 	for _, char := range text {
-		if normalizedChar, exists := polishCharMap[char]; exists {
+		if normalizedChar, exists := charMap[char]; exists {
 			result.WriteRune(normalizedChar)
 		} else {
 			result.WriteRune(char)
@@ -71,13 +103,51 @@ type SearchParams struct {
 	Province     *string
 	County       *string
 	Municipality *string
-	Limit        int
+	// Limit caps the number of results; 0 means no limit and is only accepted by streamed searches
+	Limit int
+	// Exact switches city and street matching from LIKE to case-insensitive equality
+	Exact bool
+	// Sort is a validated sort key such as "city" or "city:desc"
+	Sort string
+	// Side restricts results to odd or even house numbers ("odd", "even" or empty)
+	Side string
+	// Fuzzy enables the edit-distance city tier when all other tiers fail
+	Fuzzy bool
+	// Phonetic enables the sound-alike city tier when all other tiers, fuzzy included, fail
+	Phonetic bool
+	// Loose matches street words in any order instead of as a single substring
+	Loose bool
+	// CaseSensitive compares location filters with their stored case instead of ignoring it
+	CaseSensitive bool
+	// AssumeAllWhenEmpty treats records without house_numbers as covering every number on the street
+	AssumeAllWhenEmpty bool
+	// SkipNormalization disables the Polish normalization tiers for inputs known to match the stored spelling
+	SkipNormalization bool
+	// SkipFallback returns no results instead of dropping the house number or street when nothing matches
+	SkipFallback bool
+	// Dedupe collapses results sharing a postal code, street and city, listing their house number ranges
+	Dedupe bool
+	// GroupBy names the field results are grouped by ("postal_code" or empty for a flat list)
+	GroupBy string
 }
 
 // GetNormalizedSearchParams returns normalized search parameters for Polish character fallback
 func GetNormalizedSearchParams(params SearchParams) SearchParams {
 	normalized := SearchParams{
 		Limit: params.Limit,
+		Exact: params.Exact,
+		Sort:  params.Sort,
+		Side:  params.Side,
+		Fuzzy: params.Fuzzy,
+		Loose: params.Loose,
+
+		Phonetic:           params.Phonetic,
+		CaseSensitive:      params.CaseSensitive,
+		AssumeAllWhenEmpty: params.AssumeAllWhenEmpty,
+		SkipNormalization:  params.SkipNormalization,
+		SkipFallback:       params.SkipFallback,
+		Dedupe:             params.Dedupe,
+		GroupBy:            params.GroupBy,
 	}
 
 	if params.City != nil {
@@ -0,0 +1,54 @@
+package utils
+
+import "testing"
+
+func TestNormalizePolishText(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"Łódź", "Lodz"},
+		{"Kraków", "Krakow"},
+		{"Gdańsk", "Gdansk"},
+		{"ZAŻÓŁĆ GĘŚLĄ JAŹŃ", "ZAZOLC GESLA JAZN"},
+		{"Warszawa", "Warszawa"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizePolishText(tt.input); got != tt.expected {
+			t.Errorf("NormalizePolishText(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestNormalizeWithMap(t *testing.T) {
+	germanMap := map[rune]rune{'ä': 'a', 'ö': 'o', 'ü': 'u'}
+
+	if got := NormalizeWithMap("Görlitz", germanMap); got != "Gorlitz" {
+		t.Errorf("NormalizeWithMap(Görlitz) = %q, want Gorlitz", got)
+	}
+	// Characters outside the given map are left unchanged
+	if got := NormalizeWithMap("Łódź", germanMap); got != "Łódź" {
+		t.Errorf("NormalizeWithMap(Łódź) = %q, want Łódź", got)
+	}
+}
+
+func TestRegisterCharMappings(t *testing.T) {
+	original := activeCharMap.Load()
+	defer activeCharMap.Store(original)
+
+	RegisterCharMappings(map[rune]rune{'č': 'c', 'Č': 'C', 'ř': 'r', 'á': 'a'})
+
+	if got := NormalizePolishText("Přerov Čeladná"); got != "Prerov Celadna" {
+		t.Errorf("NormalizePolishText with Czech mappings = %q", got)
+	}
+	// Polish mappings keep working alongside registered ones
+	if got := NormalizePolishText("Łódź"); got != "Lodz" {
+		t.Errorf("NormalizePolishText(Łódź) = %q, want Lodz", got)
+	}
+	// The built-in Polish map itself is not modified
+	if _, exists := polishCharMap['č']; exists {
+		t.Error("RegisterCharMappings modified the built-in Polish map")
+	}
+}
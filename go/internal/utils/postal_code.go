@@ -0,0 +1,27 @@
+package utils
+
+import "regexp"
+
+var (
+	formattedPostalCodeRe = regexp.MustCompile(`^\d{2}-\d{3}$`)
+	dashlessPostalCodeRe  = regexp.MustCompile(`^\d{5}$`)
+	postalPrefixRe        = regexp.MustCompile(`^\d{2}$`)
+)
+
+// NormalizePostalCode accepts a postal code as NN-NNN or NNNNN and returns it as NN-NNN.
+// It reports false for any other input.
+func NormalizePostalCode(code string) (string, bool) {
+	switch {
+	case formattedPostalCodeRe.MatchString(code):
+		return code, true
+	case dashlessPostalCodeRe.MatchString(code):
+		return code[:2] + "-" + code[2:], true
+	default:
+		return "", false
+	}
+}
+
+// IsPostalCodePrefix reports whether prefix is the two-digit district part of a postal code, e.g. "31"
+func IsPostalCodePrefix(prefix string) bool {
+	return postalPrefixRe.MatchString(prefix)
+}
@@ -0,0 +1,43 @@
+package utils
+
+import "testing"
+
+func TestNormalizePostalCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"00-001", "00-001", true},
+		{"00001", "00-001", true},
+		{"31146", "31-146", true},
+		{"31-146", "31-146", true},
+		{"", "", false},
+		{"0001", "", false},
+		{"000001", "", false},
+		{"000-01", "", false},
+		{"31 146", "", false},
+		{"ab-cde", "", false},
+		{"3114a", "", false},
+	}
+
+	for _, tt := range tests {
+		got, valid := NormalizePostalCode(tt.input)
+		if got != tt.expected || valid != tt.valid {
+			t.Errorf("NormalizePostalCode(%q) = (%q, %v), want (%q, %v)", tt.input, got, valid, tt.expected, tt.valid)
+		}
+	}
+}
+
+func TestIsPostalCodePrefix(t *testing.T) {
+	for _, prefix := range []string{"00", "31", "99"} {
+		if !IsPostalCodePrefix(prefix) {
+			t.Errorf("IsPostalCodePrefix(%q) = false, want true", prefix)
+		}
+	}
+	for _, prefix := range []string{"", "3", "311", "3a", "31-", " 31"} {
+		if IsPostalCodePrefix(prefix) {
+			t.Errorf("IsPostalCodePrefix(%q) = true, want false", prefix)
+		}
+	}
+}
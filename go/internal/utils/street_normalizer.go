@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StreetPrefixes are the street-type abbreviations street names may start with: "ul" (ulica), "al" (aleja),
+// "pl" (plac) and "os" (osiedle)
+var StreetPrefixes = []string{"ul", "al", "pl", "os"}
+
+// streetPrefixRe matches a leading abbreviation from StreetPrefixes. Without the dot the abbreviation must be
+// followed by whitespace so names like "Ulanów" are left alone.
+var streetPrefixRe = regexp.MustCompile(`(?i)^(` + strings.Join(StreetPrefixes, "|") + `)(\.\s*|\s+)`)
+
+// StripStreetPrefix removes a leading street-type prefix like "ul. " from a street name
+func StripStreetPrefix(street string) string {
+	street = strings.TrimSpace(street)
+	stripped := strings.TrimSpace(streetPrefixRe.ReplaceAllString(street, ""))
+	if stripped == "" {
+		return street
+	}
+	return stripped
+}
+
+// streetAbbreviation is an abbreviation used in street names with the full forms it stands for
+type streetAbbreviation struct {
+	short string
+	forms []string
+}
+
+// streetAbbreviations lists common abbreviations with their singular, plural and inflected forms
+var streetAbbreviations = []streetAbbreviation{
+	{"al.", []string{"aleja", "aleje", "alei"}},
+	{"pl.", []string{"plac", "placu"}},
+	{"św.", []string{"święty", "święta", "świętego", "świętej", "święci", "świętych"}},
+}
+
+// maxStreetVariants caps the alternative spellings ExpandStreetAbbreviations returns for one street
+const maxStreetVariants = 16
+
+// ExpandStreetAbbreviations returns the street followed by its alternative spellings: every abbreviated word
+// ("al.", "pl.", "św.") is replaced by each of its full forms and every full form by its abbreviation, keeping
+// the word's capitalization. Words are recognized with and without Polish characters, so "Sw." expands too.
+// At most maxStreetVariants spellings are returned, none for a blank street.
+func ExpandStreetAbbreviations(street string) []string {
+	words := strings.Fields(street)
+	if len(words) == 0 {
+		return nil
+	}
+
+	variants := []string{""}
+	for _, word := range words {
+		alternatives := streetWordAlternatives(word)
+		next := make([]string, 0, min(len(variants)*len(alternatives), maxStreetVariants))
+		for _, prefix := range variants {
+			for _, alternative := range alternatives {
+				if len(next) == maxStreetVariants {
+					break
+				}
+				next = append(next, strings.TrimPrefix(prefix+" "+alternative, " "))
+			}
+		}
+		variants = next
+	}
+	return variants
+}
+
+// streetWordAlternatives returns the word followed by the spellings it can be swapped with
+func streetWordAlternatives(word string) []string {
+	key := strings.ToLower(NormalizePolishText(word))
+	for _, abbreviation := range streetAbbreviations {
+		if key == NormalizePolishText(abbreviation.short) {
+			return append([]string{word}, matchCase(word, abbreviation.forms)...)
+		}
+		for _, form := range abbreviation.forms {
+			if key == NormalizePolishText(form) {
+				return []string{word, matchCase(word, []string{abbreviation.short})[0]}
+			}
+		}
+	}
+	return []string{word}
+}
+
+// matchCase capitalizes the lowercase alternatives when the word they replace starts with a capital letter
+func matchCase(word string, alternatives []string) []string {
+	first, _ := utf8.DecodeRuneInString(word)
+	cased := make([]string, len(alternatives))
+	for i, alternative := range alternatives {
+		if unicode.IsUpper(first) {
+			r, size := utf8.DecodeRuneInString(alternative)
+			alternative = string(unicode.ToUpper(r)) + alternative[size:]
+		}
+		cased[i] = alternative
+	}
+	return cased
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStripStreetPrefix(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"ul. Marszałkowska", "Marszałkowska"},
+		{"ul Marszałkowska", "Marszałkowska"},
+		{"ul.Marszałkowska", "Marszałkowska"},
+		{"UL. Marszałkowska", "Marszałkowska"},
+		{"al. Jerozolimskie", "Jerozolimskie"},
+		{"al Jerozolimskie", "Jerozolimskie"},
+		{"pl. Zbawiciela", "Zbawiciela"},
+		{"pl Zbawiciela", "Zbawiciela"},
+		{"os. Tysiąclecia", "Tysiąclecia"},
+		{"os Tysiąclecia", "Tysiąclecia"},
+		{"  ul.  Długa  ", "Długa"},
+		{"Marszałkowska", "Marszałkowska"},
+		{"Ulanów", "Ulanów"},
+		{"Plac Zbawiciela", "Plac Zbawiciela"},
+		{"Osiedlowa", "Osiedlowa"},
+		{"ul.", "ul."},
+	}
+
+	for _, tt := range tests {
+		if got := StripStreetPrefix(tt.input); got != tt.expected {
+			t.Errorf("StripStreetPrefix(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestExpandStreetAbbreviations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"al. Jerozolimskie", []string{"al. Jerozolimskie", "aleja Jerozolimskie", "aleje Jerozolimskie", "alei Jerozolimskie"}},
+		{"Al. Jerozolimskie", []string{"Al. Jerozolimskie", "Aleja Jerozolimskie", "Aleje Jerozolimskie", "Alei Jerozolimskie"}},
+		{"Aleja Róż", []string{"Aleja Róż", "Al. Róż"}},
+		{"Aleje Jerozolimskie", []string{"Aleje Jerozolimskie", "Al. Jerozolimskie"}},
+		{"pl. Zbawiciela", []string{"pl. Zbawiciela", "plac Zbawiciela", "placu Zbawiciela"}},
+		{"Plac Zbawiciela", []string{"Plac Zbawiciela", "Pl. Zbawiciela"}},
+		{"Św. Anny", []string{"Św. Anny", "Święty Anny", "Święta Anny", "Świętego Anny", "Świętej Anny", "Święci Anny", "Świętych Anny"}},
+		{"Wszystkich Świętych", []string{"Wszystkich Świętych", "Wszystkich Św."}},
+		{"Swietego Ducha", []string{"Swietego Ducha", "Św. Ducha"}},
+		{"Marszałkowska", []string{"Marszałkowska"}},
+		{"Aleksandrowska", []string{"Aleksandrowska"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := ExpandStreetAbbreviations(tt.input); !slices.Equal(got, tt.expected) {
+			t.Errorf("ExpandStreetAbbreviations(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestExpandStreetAbbreviationsCapsVariants(t *testing.T) {
+	variants := ExpandStreetAbbreviations("pl. św. Św. Jana")
+	if len(variants) != maxStreetVariants || variants[0] != "pl. św. Św. Jana" {
+		t.Errorf("expected %d variants starting with the input, got %d: %q", maxStreetVariants, len(variants), variants)
+	}
+}
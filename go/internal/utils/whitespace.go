@@ -0,0 +1,8 @@
+package utils
+
+import "strings"
+
+// NormalizeWhitespace trims text and collapses runs of whitespace (spaces, tabs, newlines) into a single space
+func NormalizeWhitespace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
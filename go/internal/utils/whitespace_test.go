@@ -0,0 +1,26 @@
+package utils
+
+import "testing"
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"Nowy Świat", "Nowy Świat"},
+		{"Nowy   Świat", "Nowy Świat"},
+		{"Nowy\tŚwiat", "Nowy Świat"},
+		{"Nowy \t\n Świat", "Nowy Świat"},
+		{"  Warszawa", "Warszawa"},
+		{"Warszawa  ", "Warszawa"},
+		{"\tJana   Pawła\tII  ", "Jana Pawła II"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeWhitespace(tt.input); got != tt.expected {
+			t.Errorf("NormalizeWhitespace(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
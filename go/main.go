@@ -1,22 +1,37 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"time"
 
+	appconfig "postal-api/internal/config"
 	"postal-api/internal/database"
+	"postal-api/internal/grpcapi"
 	"postal-api/internal/routes"
+	"postal-api/internal/services"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+// Build metadata, set with -ldflags "-X main.version=... -X main.commit=... -X main.buildDate=..."
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
 func main() {
+	startTime := time.Now()
+
 	// Check if database exists
 	if !database.CheckDatabaseExists() {
-		fmt.Println("Database file postal_codes.db not found. Please run create_db.py first.")
+		fmt.Println("Database file postal_codes.db not found. Please run `go run ./cmd/import` first.")
 		os.Exit(1)
 	}
 
@@ -26,8 +41,24 @@ func main() {
 	}
 	defer database.Close()
 
+	// Reopen the database when it becomes unreachable or its file is replaced, dropping results cached from the old one
+	database.OnReload(services.ClearCaches)
+	go database.MonitorConnection(context.Background(), appconfig.DBHealthCheckInterval())
+
+	// Optionally build the full-text index; /search/fts falls back to LIKE matching without it
+	if !database.HasFTS() && appconfig.BuildFTSIndex() {
+		if err := database.BuildFTSIndex(); err != nil {
+			log.Printf("Full-text index unavailable, using LIKE fallback: %v", err)
+		}
+	}
+
+	// Run in release mode, which hides internal error details and debug output from clients,
+	// unless GIN_MODE explicitly asks for debug mode
+	if os.Getenv(gin.EnvGinMode) == "" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
 	// Create Gin router with logging
-	gin.SetMode(gin.DebugMode)
 	router := gin.Default()
 
 	// Configure CORS to allow requests from the frontend
@@ -40,9 +71,33 @@ func main() {
 	// Add logging middleware for errors
 	router.Use(gin.Logger(), gin.Recovery())
 
+	// API keys gate every endpoint except /health when configured
+	apiKeys, err := appconfig.APIKeys()
+	if err != nil {
+		log.Fatalf("Failed to load API keys: %v", err)
+	}
+
 	// Register routes
+	routes.SetAPIKeys(apiKeys)
+	routes.SetBuildInfo(routes.BuildInfo{Version: version, Commit: commit, BuildDate: buildDate})
+	routes.SetStartTime(startTime)
 	routes.RegisterRoutes(router)
 
+	// Serve the gRPC API on its own port when GRPC_PORT is set
+	if grpcPort := appconfig.GRPCPort(); grpcPort > 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+		grpcServer := grpcapi.NewServer(apiKeys)
+		fmt.Printf("Starting postal code gRPC server on :%d\n", grpcPort)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+	}
+
 	// Start server on port 5003
 	fmt.Println("Starting postal code API server on :5003")
 	if err := http.ListenAndServe(":5003", router); err != nil {